@@ -1,4 +1,24 @@
 <?xml version="1.0" encoding="UTF-8"?>
 <tileset version="1.10" tiledversion="1.11.2" name="GrassTiles" tilewidth="16" tileheight="16" tilecount="42" columns="6">
  <image source="../res/GrassTiles.png" width="96" height="120"/>
+ <tile id="37">
+  <properties>
+   <property name="collision" value="oneway"/>
+  </properties>
+ </tile>
+ <tile id="38">
+  <properties>
+   <property name="collision" value="oneway"/>
+  </properties>
+ </tile>
+ <tile id="39">
+  <properties>
+   <property name="collision" value="oneway"/>
+  </properties>
+ </tile>
+ <tile id="40">
+  <properties>
+   <property name="collision" value="oneway"/>
+  </properties>
+ </tile>
 </tileset>
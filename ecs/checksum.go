@@ -0,0 +1,105 @@
+package ecs
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Returns a checksum of every component of every entity, and of every resource
+// Two runs which are in the same state have the same checksum
+// Pointers, interfaces, maps and functions are skipped, since they don't hold world state (e.g. images)
+func (manager *Manager) Checksum() uint64 {
+	hash := fnv.New64a()
+
+	for _, componentType := range manager.ComponentTypes() {
+		hash.Write([]byte(componentType))
+
+		// The whole set is read at once, instead of calling into it for every entity
+		set := reflect.ValueOf(manager.ComponentPool[componentType])
+
+		indicesMethod := set.MethodByName("Indices")
+		valuesMethod := set.MethodByName("Values")
+		if !indicesMethod.IsValid() || !valuesMethod.IsValid() {
+			panic("Indices or Values method for sparse set not found")
+		}
+
+		indices := indicesMethod.Call(nil)[0].Interface().([]int)
+		values := valuesMethod.Call(nil)[0]
+
+		// Entities are visited in order of their ids, since the order of the set changes when components are deleted
+		order := make([]int, len(indices))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.Slice(order, func(a, b int) bool {
+			return indices[order[a]] < indices[order[b]]
+		})
+
+		for _, i := range order {
+			writeUint(hash, uint64(indices[i]))
+			writeValue(hash, values.Index(i))
+		}
+	}
+
+	// Resources are visited in order of their types
+	resourceTypes := make([]string, 0, len(manager.Resources))
+
+	for resourceType := range manager.Resources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		hash.Write([]byte(resourceType))
+
+		// Resources are stored as pointers
+		writeValue(hash, reflect.ValueOf(manager.Resources[resourceType]).Elem())
+	}
+
+	return hash.Sum64()
+}
+
+func writeUint(hash hash.Hash64, value uint64) {
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], value)
+
+	hash.Write(buffer[:])
+}
+
+// Writes the bits of the value to the hash
+func writeValue(hash hash.Hash64, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			writeUint(hash, 1)
+		} else {
+			writeUint(hash, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(hash, uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(hash, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		// The exact bits, so even the smallest difference changes the checksum
+		writeUint(hash, math.Float64bits(value.Float()))
+	case reflect.String:
+		writeUint(hash, uint64(value.Len()))
+		hash.Write([]byte(value.String()))
+	case reflect.Struct:
+		for i := range value.NumField() {
+			writeValue(hash, value.Field(i))
+		}
+	case reflect.Array, reflect.Slice:
+		writeUint(hash, uint64(value.Len()))
+
+		for i := range value.Len() {
+			writeValue(hash, value.Index(i))
+		}
+	}
+}
@@ -2,6 +2,7 @@ package ecs
 
 import (
 	"reflect"
+	"sort"
 
 	// Game packages
 	"github.com/plutial/game/gfx"
@@ -15,6 +16,9 @@ type Manager struct {
 	// Component storage
 	ComponentPool map[string]any
 
+	// Global data which does not belong to any entity
+	Resources map[string]any
+
 	// Entity count
 	Size int
 
@@ -28,6 +32,7 @@ func NewManager() Manager {
 
 	// Manager
 	manager.ComponentPool = make(map[string]any)
+	manager.Resources = make(map[string]any)
 
 	// Entity exists
 	RegisterComponent[Alive](&manager)
@@ -59,6 +64,20 @@ func (manager *Manager) Render() {
 	}
 }
 
+// Returns the names of the registered component types in alphabetical order
+// Maps are iterated in a random order, so this is used to iterate over the components in the same order every time
+func (manager *Manager) ComponentTypes() []string {
+	componentTypes := make([]string, 0, len(manager.ComponentPool))
+
+	for componentType := range manager.ComponentPool {
+		componentTypes = append(componentTypes, componentType)
+	}
+
+	sort.Strings(componentTypes)
+
+	return componentTypes
+}
+
 func (manager *Manager) DeleteEntities() {
 	for _, id := range manager.ToDelete {
 		// Check that the entity is alive before removing the alive component
@@ -66,9 +85,15 @@ func (manager *Manager) DeleteEntities() {
 			continue
 		}
 
+		// Release the texture of the sprite
+		if HasComponent[gfx.Sprite](manager, id) {
+			GetComponent[gfx.Sprite](manager, id).Destroy()
+		}
+
 		// Remove all of the entity's components
-		for _, value := range manager.ComponentPool {
-			value := reflect.ValueOf(value)
+		// The components are removed in the same order every time
+		for _, componentType := range manager.ComponentTypes() {
+			value := reflect.ValueOf(manager.ComponentPool[componentType])
 			in := make([]reflect.Value, 0)
 			in = append(in, reflect.ValueOf(id))
 
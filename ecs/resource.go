@@ -0,0 +1,38 @@
+package ecs
+
+import (
+	"fmt"
+
+	"github.com/plutial/game/util"
+)
+
+// Resources are global data which do not belong to any entity
+// There can only be one resource of each type
+
+// Add a resource with the resource type
+func AddResource[T any](manager *Manager, resource T) *T {
+	address := &resource
+	manager.Resources[util.GetType[T]()] = address
+
+	// Return the address of the resource
+	return address
+}
+
+// Check if the manager has a resource
+func HasResource[T any](manager *Manager) bool {
+	_, ok := manager.Resources[util.GetType[T]()].(*T)
+
+	return ok
+}
+
+// Get the address of the resource
+func GetResource[T any](manager *Manager) *T {
+	address, ok := manager.Resources[util.GetType[T]()].(*T)
+
+	if !ok {
+		message := fmt.Sprintf("Resource type %v not found", util.GetType[T]())
+		panic(message)
+	}
+
+	return address
+}
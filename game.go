@@ -1,12 +1,15 @@
 package main
 
 import (
+	"log"
+
 	// Ebitengine
 	"github.com/hajimehoshi/ebiten/v2"
 
 	// Game packages
 	"github.com/plutial/game/ecs"
 	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/input"
 	"github.com/plutial/game/physics"
 	"github.com/plutial/game/world"
 )
@@ -16,6 +19,12 @@ type Game struct {
 
 	// Screen size
 	ScreenWidth, ScreenHeight int
+
+	// Number of steps taken
+	Tick int
+
+	// Checksum of the world after each step in determinism mode
+	Checksums []uint64
 }
 
 func NewGame(width, height int, title string) Game {
@@ -33,20 +42,65 @@ func NewGame(width, height int, title string) Game {
 
 	// Sprite for renderingame
 	ecs.RegisterComponent[gfx.Sprite](&game.Manager)
+	ecs.RegisterComponent[gfx.Animation](&game.Manager)
+	ecs.RegisterComponent[gfx.Animator](&game.Manager)
 
 	// Physics components
 	ecs.RegisterComponent[physics.Body](&game.Manager)
 	ecs.RegisterComponent[physics.Force](&game.Manager)
+	ecs.RegisterComponent[physics.Slope](&game.Manager)
+	ecs.RegisterComponent[physics.Path](&game.Manager)
+	ecs.RegisterComponent[physics.BodyShape](&game.Manager)
+	ecs.RegisterComponent[physics.PhysicsMaterial](&game.Manager)
+	ecs.RegisterComponent[physics.Bullet](&game.Manager)
+	ecs.RegisterComponent[physics.Fluid](&game.Manager)
+	ecs.RegisterComponent[physics.BodyType](&game.Manager)
+	ecs.RegisterComponent[physics.GravityZone](&game.Manager)
+	ecs.RegisterComponent[physics.ForceField](&game.Manager)
+	ecs.RegisterComponent[physics.Verlet](&game.Manager)
 
 	// Entity traits
-	ecs.RegisterComponent[physics.Jump](&game.Manager)
+	ecs.RegisterComponent[physics.PlatformerController](&game.Manager)
+	ecs.RegisterComponent[physics.Dash](&game.Manager)
+	ecs.RegisterComponent[physics.Grapple](&game.Manager)
 
 	// Tags
 	ecs.RegisterComponent[world.PlayerTag](&game.Manager)
 	ecs.RegisterComponent[world.EnemyTag](&game.Manager)
 	ecs.RegisterComponent[world.TileTag](&game.Manager)
+	ecs.RegisterComponent[world.PlatformTag](&game.Manager)
+	ecs.RegisterComponent[world.ClimbableTag](&game.Manager)
 	ecs.RegisterComponent[world.ProjectileTag](&game.Manager)
 
+	// Physics world for collision queries
+	physicsWorld := ecs.AddResource(&game.Manager, physics.NewWorld(64))
+
+	// Shapes recorded during the physics step for the debug overlay, including the queries of the physics world
+	physicsWorld.Debug = ecs.AddResource(&game.Manager, physics.NewDebugData())
+
+	// Gravity, movement and default materials
+	ecs.AddResource(&game.Manager, physics.NewPhysicsSettings())
+
+	// Input of the player for the current step
+	ecs.AddResource(&game.Manager, world.PlayerInput{})
+
+	// Splashes of the current step, for effects and audio
+	ecs.AddResource(&game.Manager, world.Splashes{})
+
+	// Contacts of the last physics step
+	ecs.AddResource(&game.Manager, world.ContactReport{})
+
+	// Animation events of the current step
+	ecs.AddResource(&game.Manager, world.AnimationEvents{})
+
+	// View of the world which follows the player
+	ecs.AddResource(&game.Manager, gfx.NewCamera(physics.NewVector2f(float64(width), float64(height))))
+
+	// Start loading the textures in the background
+	if err := gfx.Assets.LoadManifest("assets/manifest.json"); err != nil {
+		log.Println(err)
+	}
+
 	// Load maps
 	world.LoadMap(&game.Manager, "assets/maps/map0.json")
 
@@ -56,6 +110,13 @@ func NewGame(width, height int, title string) Game {
 	// Create the player
 	world.NewPlayer(&game.Manager)
 
+	// Build the physics world, so it can be queried before the first physics update
+	world.UpdatePhysicsWorld(&game.Manager)
+
+	// Start the view on the player
+	world.UpdateCamera(&game.Manager)
+	ecs.GetResource[gfx.Camera](&game.Manager).Snap()
+
 	return game
 }
 
@@ -66,33 +127,92 @@ func (game *Game) Run() {
 }
 
 func (game *Game) Update() error {
+	// Show or hide the physics debug overlay
+	if input.IsKeyPressed(input.KeyF3) {
+		world.ToggleDebug(&game.Manager)
+	}
+
+	// Read the input once, so every system sees the same input
+	// The mouse is aimed through the view of the camera
+	game.Step(world.ReadPlayerInput(*ecs.GetResource[gfx.Camera](&game.Manager)))
+
+	return nil
+}
+
+// Advance the game by one step with the input
+// In determinism mode, the same input from the same state always gives the same state
+func (game *Game) Step(playerInput world.PlayerInput) {
+	*ecs.GetResource[world.PlayerInput](&game.Manager) = playerInput
+
+	// Only show the debug shapes of this step
+	ecs.GetResource[physics.DebugData](&game.Manager).Clear()
+
+	// Only report the splashes and animation events of this step
+	ecs.GetResource[world.Splashes](&game.Manager).Clear()
+	ecs.GetResource[world.AnimationEvents](&game.Manager).Clear()
+
 	// Updating
 	game.Manager.Update()
 
 	// Take in input and change it to movement
 	world.UpdateMovement(&game.Manager)
 
+	// Move the enemies by their controllers
+	world.UpdateEnemies(&game.Manager)
+
 	// Attacking
 	world.EntityAttack(&game.Manager)
 
 	// Charging
 	world.EntityCharge(&game.Manager)
 
+	// Shooting and reeling the grappling hook
+	world.UpdateGrapple(&game.Manager)
+
+	// Move the platforms and their riders
+	world.UpdatePlatforms(&game.Manager)
+
 	// Update the physics world
 	world.UpdatePhysics(&game.Manager)
 
+	// Swing the ropes after the bodies have moved
+	world.UpdateRopes(&game.Manager)
+
 	// Update the sprite after all the physics calculations have finished
 	world.UpdateSprite(&game.Manager)
 
-	return nil
+	// Advance the animations of the sprites
+	world.UpdateAnimations(&game.Manager)
+
+	// Follow the player with the camera
+	world.UpdateCamera(&game.Manager)
+
+	game.Tick++
+
+	// Record the state of the world, so runs can be compared step by step
+	if ecs.GetResource[physics.PhysicsSettings](&game.Manager).Deterministic {
+		game.Checksums = append(game.Checksums, game.Manager.Checksum())
+	}
 }
 
 func (game *Game) Draw(screen *ebiten.Image) {
 	// Update the screen
 	*gfx.GetScreen() = screen
 
+	// Draw through the view of the camera
+	gfx.SetView(*ecs.GetResource[gfx.Camera](&game.Manager))
+
 	// Render entities
 	game.Manager.Render()
+
+	// Render ropes over the entities
+	world.RenderRopes(&game.Manager)
+
+	// Draw the physics debug overlay on top
+	world.RenderDebug(&game.Manager)
+
+	// Draw the primitives which are left in the batch
+	gfx.Flush()
 }
 
 func (game *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/physics"
+	"github.com/plutial/game/world"
+)
+
+// Returns the input of the player for each step of a scripted run
+// The player runs back and forth, jumps, dashes and shoots
+func inputScript(steps int) []world.PlayerInput {
+	script := make([]world.PlayerInput, steps)
+
+	for i := range script {
+		playerInput := &script[i]
+
+		playerInput.Controller.Right = i%120 < 60
+		playerInput.Controller.Left = i%120 >= 80
+		playerInput.Controller.Down = i%240 >= 200
+		playerInput.Controller.JumpPressed = i%45 == 0
+		playerInput.Controller.JumpHeld = i%45 < 15
+		playerInput.Controller.DashPressed = i%100 == 50
+
+		playerInput.Attack = i%70 == 10
+		playerInput.Aim = physics.NewVector2f(float64(i%300), 64)
+	}
+
+	return script
+}
+
+// Plays the input script in a new game, and returns the checksum of each step
+// The game is never run, so no window is opened
+func playScript(script []world.PlayerInput) []uint64 {
+	game := NewGame(800, 450, "Test")
+	ecs.GetResource[physics.PhysicsSettings](&game.Manager).Deterministic = true
+
+	for _, playerInput := range script {
+		game.Step(playerInput)
+	}
+
+	return game.Checksums
+}
+
+func TestStepDeterminism(t *testing.T) {
+	script := inputScript(600)
+
+	first := playScript(script)
+	second := playScript(script)
+
+	if len(first) != len(script) || len(second) != len(script) {
+		t.Fatalf("recorded %d and %d checksums, want %d", len(first), len(second), len(script))
+	}
+
+	for step := range script {
+		if first[step] != second[step] {
+			t.Fatalf("checksums differ at step %d: %x != %x", step, first[step], second[step])
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package gfx
+
+import (
+	"math"
+	"slices"
+
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+const (
+	// How a clip plays once it reaches its last frame
+	PlayLoop int = iota
+	PlayPingPong
+	PlayOnce
+)
+
+// Image of a clip, which is shown for a number of steps
+type Frame struct {
+	// The source co-ordinates and size in the texture
+	Source physics.Body
+
+	// Number of steps the frame is shown for
+	Duration float64
+
+	// Name of the event which is fired when the frame is reached
+	// No event is fired if the name is empty
+	Event string
+}
+
+// Named sequence of frames
+type Clip struct {
+	Name   string
+	Frames []Frame
+	Mode   int
+}
+
+// Event fired by an animation
+type AnimationEvent struct {
+	// Entity id of the animation
+	Id int
+
+	Clip  string
+	Frame int
+	Name  string
+}
+
+// Plays clips on a sprite
+type Animation struct {
+	Clips []Clip
+
+	// Name of the clip which is playing
+	Clip string
+
+	// Frame of the clip which is shown, and the number of steps it has been shown for
+	Frame   int
+	Elapsed float64
+
+	// Multiplier of the speed of the clips
+	Speed float64
+
+	// Direction the frames are played in, forwards (1) or backwards (-1) in a ping-pong clip
+	Direction int
+
+	// The clip played once and reached its last frame
+	Finished bool
+
+	// Events fired since they were last cleared
+	Events []AnimationEvent
+}
+
+// Creates a new animation without clips
+func NewAnimation() Animation {
+	animation := Animation{}
+
+	animation.Clips = make([]Clip, 0)
+	animation.Speed = 1
+	animation.Direction = 1
+	animation.Events = make([]AnimationEvent, 0)
+
+	return animation
+}
+
+// Adds the clip, or replaces the clip with the same name
+// The frames are copied, so the events of the animation are not shared with other animations
+func (animation *Animation) AddClip(clip Clip) {
+	clip.Frames = slices.Clone(clip.Frames)
+
+	for i := range animation.Clips {
+		if animation.Clips[i].Name == clip.Name {
+			animation.Clips[i] = clip
+			return
+		}
+	}
+
+	animation.Clips = append(animation.Clips, clip)
+}
+
+// Returns the clip with the name
+func (animation Animation) FindClip(name string) (Clip, bool) {
+	for _, clip := range animation.Clips {
+		if clip.Name == name {
+			return clip, true
+		}
+	}
+
+	return Clip{}, false
+}
+
+// Fires the event when the frame of the clip is reached
+func (animation *Animation) AddEvent(clipName string, frame int, event string) {
+	for i := range animation.Clips {
+		if animation.Clips[i].Name == clipName && frame < len(animation.Clips[i].Frames) {
+			animation.Clips[i].Frames[frame].Event = event
+		}
+	}
+}
+
+// Starts playing the clip from its first frame
+// The clip keeps playing if it is already playing
+func (animation *Animation) Play(name string) {
+	if animation.Clip == name {
+		return
+	}
+
+	animation.Clip = name
+	animation.Frame = 0
+	animation.Elapsed = 0
+	animation.Direction = 1
+	animation.Finished = false
+
+	// The first frame is reached at once
+	animation.fire()
+}
+
+// Removes the events which were fired
+// This is called at the start of each step, before the clip is played and updated
+func (animation *Animation) ClearEvents() {
+	animation.Events = animation.Events[:0]
+}
+
+// Advances the clip by one step, and records the events of the frames which were reached
+func (animation *Animation) Update() {
+	clip, ok := animation.FindClip(animation.Clip)
+	if !ok || len(clip.Frames) == 0 || animation.Finished {
+		return
+	}
+
+	animation.Elapsed += animation.Speed
+
+	// Fast animations can skip over several frames in one step
+	for animation.Elapsed >= clip.Frames[animation.Frame].duration() && !animation.Finished {
+		animation.Elapsed -= clip.Frames[animation.Frame].duration()
+		animation.advance(clip)
+	}
+}
+
+// Returns the number of steps the frame is shown for
+// Frames without a duration are shown for one step
+func (frame Frame) duration() float64 {
+	if frame.Duration <= 0 {
+		return 1
+	}
+
+	return frame.Duration
+}
+
+// Moves to the next frame of the clip
+func (animation *Animation) advance(clip Clip) {
+	last := len(clip.Frames) - 1
+	next := animation.Frame + animation.Direction
+
+	switch clip.Mode {
+	case PlayLoop:
+		if next > last {
+			next = 0
+		}
+	case PlayPingPong:
+		if next > last || next < 0 {
+			animation.Direction = -animation.Direction
+			next = max(0, min(last, animation.Frame+animation.Direction))
+		}
+	case PlayOnce:
+		if next > last {
+			animation.Finished = true
+			return
+		}
+	}
+
+	// A clip of one frame does not reach it again
+	if next == animation.Frame {
+		return
+	}
+
+	animation.Frame = next
+	animation.fire()
+}
+
+// Records the event of the frame which is shown
+func (animation *Animation) fire() {
+	clip, ok := animation.FindClip(animation.Clip)
+	if !ok || animation.Frame >= len(clip.Frames) {
+		return
+	}
+
+	if event := clip.Frames[animation.Frame].Event; event != "" {
+		animation.Events = append(animation.Events, AnimationEvent{
+			Clip:  clip.Name,
+			Frame: animation.Frame,
+			Name:  event,
+		})
+	}
+}
+
+// Returns the source co-ordinates and size of the frame which is shown
+// Returns false if no clip is playing
+func (animation Animation) Source() (physics.Body, bool) {
+	clip, ok := animation.FindClip(animation.Clip)
+	if !ok || animation.Frame >= len(clip.Frames) {
+		return physics.Body{}, false
+	}
+
+	return clip.Frames[animation.Frame].Source, true
+}
+
+// Picks the clip of an animation from the movement of the body
+type Animator struct {
+	// Names of the clips for each state
+	Idle, Run, Jump, Fall string
+
+	// Slowest speed along the ground which counts as running
+	RunSpeed float64
+}
+
+// Creates a new animator with clips named after the states
+func NewAnimator() Animator {
+	animator := Animator{}
+
+	animator.Idle = "idle"
+	animator.Run = "run"
+	animator.Jump = "jump"
+	animator.Fall = "fall"
+	animator.RunSpeed = 0.1
+
+	return animator
+}
+
+// Returns the name of the clip for the movement of the body, and the way the body faces
+// The body faces left (-1) or right (1) along the ground, or keeps facing the same way (0) while it stands still
+// Bodies rise and fall against the gravity, and run along the ground
+func (animator Animator) Pick(force physics.Force) (string, int) {
+	down := force.Gravity.Normalize()
+	if down.Magnitude() == 0 {
+		down = physics.NewVector2f(0, 1)
+	}
+
+	// The ground runs to the right of the gravity
+	right := down.Rotate(-math.Pi / 2)
+
+	fall := force.Acceleration.Dot(down)
+	run := force.Acceleration.Dot(right)
+
+	facing := 0
+	if run > animator.RunSpeed {
+		facing = 1
+	} else if run < -animator.RunSpeed {
+		facing = -1
+	}
+
+	switch {
+	case !force.Collisions.Grounded(force.Gravity) && fall < 0:
+		return animator.Jump, facing
+	case !force.Collisions.Grounded(force.Gravity):
+		return animator.Fall, facing
+	case facing != 0:
+		return animator.Run, facing
+	}
+
+	return animator.Idle, facing
+}
@@ -0,0 +1,168 @@
+package gfx
+
+import (
+	"slices"
+	"testing"
+
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+// Returns an animation with one clip, whose frames are shown for the duration
+func newTestAnimation(mode int, frames int, duration float64) Animation {
+	clip := Clip{Name: "clip", Mode: mode}
+
+	for i := range frames {
+		clip.Frames = append(clip.Frames, Frame{
+			Source:   physics.NewBody(physics.NewVector2f(float64(i)*16, 0), physics.NewVector2f(16, 16)),
+			Duration: duration,
+		})
+	}
+
+	animation := NewAnimation()
+	animation.AddClip(clip)
+
+	return animation
+}
+
+// Returns the frame which is shown after each update
+func playFrames(animation *Animation, steps int) []int {
+	frames := make([]int, steps)
+
+	for i := range frames {
+		animation.Update()
+		frames[i] = animation.Frame
+	}
+
+	return frames
+}
+
+func TestAnimationModes(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		mode     int
+		frames   int
+		duration float64
+		want     []int
+	}{
+		{"loop", PlayLoop, 3, 2, []int{0, 1, 1, 2, 2, 0, 0, 1}},
+		{"ping-pong", PlayPingPong, 3, 1, []int{1, 2, 1, 0, 1, 2, 1}},
+		{"once", PlayOnce, 3, 1, []int{1, 2, 2, 2}},
+		{"one frame", PlayPingPong, 1, 1, []int{0, 0, 0}},
+		{"no duration", PlayLoop, 2, 0, []int{1, 0, 1}},
+	} {
+		animation := newTestAnimation(test.mode, test.frames, test.duration)
+		animation.Play("clip")
+
+		if got := playFrames(&animation, len(test.want)); !slices.Equal(got, test.want) {
+			t.Errorf("%s: frames = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAnimationOnceFinishes(t *testing.T) {
+	animation := newTestAnimation(PlayOnce, 2, 1)
+	animation.Play("clip")
+
+	animation.Update()
+
+	if animation.Finished {
+		t.Fatalf("finished before the last frame was shown")
+	}
+
+	animation.Update()
+
+	if !animation.Finished {
+		t.Errorf("not finished after the last frame was shown")
+	}
+
+	// Playing the clip again does not restart it, but playing another clip does
+	animation.Play("clip")
+
+	if !animation.Finished || animation.Frame != 1 {
+		t.Errorf("playing the same clip restarted it, frame %d", animation.Frame)
+	}
+
+	animation.Play("other")
+	animation.Play("clip")
+
+	if animation.Finished || animation.Frame != 0 {
+		t.Errorf("replaying the clip: finished = %v, frame %d, want false and 0", animation.Finished, animation.Frame)
+	}
+}
+
+func TestAnimationFrameSkipping(t *testing.T) {
+	animation := newTestAnimation(PlayLoop, 4, 1)
+	animation.AddEvent("clip", 1, "one")
+	animation.AddEvent("clip", 2, "two")
+	animation.AddEvent("clip", 3, "three")
+
+	animation.Speed = 3
+	animation.Play("clip")
+	animation.Update()
+
+	if animation.Frame != 3 {
+		t.Errorf("frame = %d, want 3", animation.Frame)
+	}
+
+	// The events of the skipped frames are still fired, in order
+	names := make([]string, 0)
+	for _, event := range animation.Events {
+		names = append(names, event.Name)
+	}
+
+	if want := []string{"one", "two", "three"}; !slices.Equal(names, want) {
+		t.Errorf("events = %v, want %v", names, want)
+	}
+
+	// The time left over is kept for the next step
+	animation.Speed = 0.5
+	animation.Update()
+
+	if animation.Frame != 3 || animation.Elapsed != 0.5 {
+		t.Errorf("frame %d, elapsed %v, want 3 and 0.5", animation.Frame, animation.Elapsed)
+	}
+}
+
+func TestAnimationEvents(t *testing.T) {
+	animation := newTestAnimation(PlayLoop, 2, 1)
+	animation.AddEvent("clip", 0, "start")
+	animation.AddEvent("clip", 1, "step")
+
+	// The first frame is reached when the clip is played
+	animation.Play("clip")
+
+	if len(animation.Events) != 1 || animation.Events[0].Name != "start" || animation.Events[0].Frame != 0 {
+		t.Errorf("events after playing = %v, want start on frame 0", animation.Events)
+	}
+
+	// Events stay until they are cleared
+	animation.Update()
+
+	if len(animation.Events) != 2 || animation.Events[1].Name != "step" || animation.Events[1].Clip != "clip" {
+		t.Errorf("events after updating = %v, want start and step", animation.Events)
+	}
+
+	animation.ClearEvents()
+
+	if len(animation.Events) != 0 {
+		t.Errorf("events after clearing = %v, want none", animation.Events)
+	}
+
+	// Looping back to the first frame fires its event again
+	animation.Update()
+
+	if len(animation.Events) != 1 || animation.Events[0].Name != "start" {
+		t.Errorf("events after looping = %v, want start", animation.Events)
+	}
+
+	// Events of an animation are not shared with the clips it was made from
+	other := newTestAnimation(PlayLoop, 2, 1)
+	clip, _ := other.FindClip("clip")
+	animation.AddClip(clip)
+	animation.AddEvent("clip", 0, "changed")
+
+	if clip.Frames[0].Event != "" {
+		t.Errorf("adding an event changed the clip it was copied from")
+	}
+}
@@ -0,0 +1,186 @@
+package gfx
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+// Number of physics steps in a second, which the durations in milliseconds are turned into
+const stepsPerSecond = 60
+
+// Texture and the clips of a sprite sheet
+type SpriteSheet struct {
+	// Path of the texture
+	Image string
+
+	Clips []Clip
+}
+
+// Frame of a sprite sheet exported from Aseprite
+type asepriteFrame struct {
+	Frame struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+		W float64 `json:"w"`
+		H float64 `json:"h"`
+	} `json:"frame"`
+
+	// Duration in milliseconds
+	Duration float64 `json:"duration"`
+}
+
+// Tagged range of frames of a sprite sheet exported from Aseprite
+type asepriteTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+
+	// Number of times the tag is played, where an empty or zero count plays forever
+	Repeat string `json:"repeat"`
+}
+
+// Sprite sheet JSON exported from Aseprite
+// The frames are either an array or a hash, so they are decoded separately
+type asepriteSheet struct {
+	Frames json.RawMessage `json:"frames"`
+
+	Meta struct {
+		Image     string        `json:"image"`
+		FrameTags []asepriteTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// Loads the clips of a sprite sheet exported from Aseprite as JSON
+// Each tag is a clip, and a sheet without tags is one looping clip named "default"
+// The path of the image is relative to the JSON file
+func LoadAseprite(path string) (SpriteSheet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SpriteSheet{}, err
+	}
+
+	var sheetData asepriteSheet
+
+	if err := json.Unmarshal(data, &sheetData); err != nil {
+		return SpriteSheet{}, err
+	}
+
+	asepriteFrames, err := decodeAsepriteFrames(sheetData.Frames)
+	if err != nil {
+		return SpriteSheet{}, err
+	}
+
+	// Turn the frames into the frames of the clips
+	frames := make([]Frame, len(asepriteFrames))
+
+	for i, frame := range asepriteFrames {
+		frames[i] = Frame{
+			Source: physics.NewBody(
+				physics.NewVector2f(frame.Frame.X, frame.Frame.Y),
+				physics.NewVector2f(frame.Frame.W, frame.Frame.H),
+			),
+			Duration: frame.Duration * stepsPerSecond / 1000,
+		}
+	}
+
+	sheet := SpriteSheet{}
+	sheet.Image = filepath.Join(filepath.Dir(path), sheetData.Meta.Image)
+	sheet.Clips = make([]Clip, 0)
+
+	if len(sheetData.Meta.FrameTags) == 0 {
+		sheet.Clips = append(sheet.Clips, Clip{Name: "default", Frames: frames, Mode: PlayLoop})
+
+		return sheet, nil
+	}
+
+	for _, tag := range sheetData.Meta.FrameTags {
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			continue
+		}
+
+		clip := Clip{Name: tag.Name, Mode: PlayLoop}
+
+		// Copy the frames, so reversing them does not change the other clips
+		clip.Frames = append(clip.Frames, frames[tag.From:tag.To+1]...)
+
+		switch tag.Direction {
+		case "reverse":
+			slices.Reverse(clip.Frames)
+		case "pingpong":
+			clip.Mode = PlayPingPong
+		case "pingpong_reverse":
+			slices.Reverse(clip.Frames)
+			clip.Mode = PlayPingPong
+		}
+
+		// Tags which play once stop at their last frame
+		if tag.Repeat == "1" && clip.Mode == PlayLoop {
+			clip.Mode = PlayOnce
+		}
+
+		sheet.Clips = append(sheet.Clips, clip)
+	}
+
+	return sheet, nil
+}
+
+// Decodes the frames in the order of the sheet, from an array or a hash
+func decodeAsepriteFrames(data json.RawMessage) ([]asepriteFrame, error) {
+	frames := make([]asepriteFrame, 0)
+
+	data = bytes.TrimSpace(data)
+
+	if len(data) == 0 {
+		return frames, nil
+	}
+
+	// Array of frames
+	if data[0] == '[' {
+		err := json.Unmarshal(data, &frames)
+
+		return frames, err
+	}
+
+	// Hash of frames by their file names, which are kept in the order of the file
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	// The opening brace
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	for decoder.More() {
+		// The file name of the frame
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		var frame asepriteFrame
+
+		if err := decoder.Decode(&frame); err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
+
+// Creates an animation with the clips of the sheet
+func (sheet SpriteSheet) Animation() Animation {
+	animation := NewAnimation()
+
+	for _, clip := range sheet.Clips {
+		animation.AddClip(clip)
+	}
+
+	return animation
+}
@@ -0,0 +1,123 @@
+package gfx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDecodeAsepriteFrames(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+		want []float64
+	}{
+		{"array", `[
+			{"frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "duration": 100},
+			{"frame": {"x": 16, "y": 0, "w": 16, "h": 16}, "duration": 200}
+		]`, []float64{0, 16}},
+		// The frames of a hash are kept in the order of the file, not the order of their names
+		{"hash", `{
+			"b.aseprite": {"frame": {"x": 32, "y": 0, "w": 16, "h": 16}, "duration": 100},
+			"a.aseprite": {"frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "duration": 100},
+			"c.aseprite": {"frame": {"x": 16, "y": 0, "w": 16, "h": 16}, "duration": 100}
+		}`, []float64{32, 0, 16}},
+		{"empty", ``, []float64{}},
+		{"empty hash", `{}`, []float64{}},
+	} {
+		frames, err := decodeAsepriteFrames(json.RawMessage(test.data))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+
+		positions := make([]float64, 0)
+		for _, frame := range frames {
+			positions = append(positions, frame.Frame.X)
+		}
+
+		if !slices.Equal(positions, test.want) {
+			t.Errorf("%s: frames at %v, want %v", test.name, positions, test.want)
+		}
+	}
+
+	// Broken hashes are reported
+	if _, err := decodeAsepriteFrames(json.RawMessage(`{"a": {"frame": 1}}`)); err == nil {
+		t.Errorf("decoding a broken hash did not fail")
+	}
+}
+
+func TestLoadAseprite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sheet.json")
+
+	data := `{
+		"frames": [
+			{"frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "duration": 100},
+			{"frame": {"x": 16, "y": 0, "w": 16, "h": 16}, "duration": 50},
+			{"frame": {"x": 32, "y": 0, "w": 16, "h": 16}, "duration": 100}
+		],
+		"meta": {
+			"image": "sheet.png",
+			"frameTags": [
+				{"name": "forward", "from": 0, "to": 2, "direction": "forward"},
+				{"name": "reverse", "from": 0, "to": 2, "direction": "reverse"},
+				{"name": "pingpong", "from": 1, "to": 2, "direction": "pingpong"},
+				{"name": "once", "from": 0, "to": 1, "direction": "forward", "repeat": "1"},
+				{"name": "broken", "from": 2, "to": 5, "direction": "forward"}
+			]
+		}
+	}`
+
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sheet, err := LoadAseprite(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := filepath.Join(filepath.Dir(path), "sheet.png"); sheet.Image != want {
+		t.Errorf("image = %q, want %q", sheet.Image, want)
+	}
+
+	for _, test := range []struct {
+		name      string
+		mode      int
+		positions []float64
+	}{
+		{"forward", PlayLoop, []float64{0, 16, 32}},
+		{"reverse", PlayLoop, []float64{32, 16, 0}},
+		{"pingpong", PlayPingPong, []float64{16, 32}},
+		{"once", PlayOnce, []float64{0, 16}},
+	} {
+		clip, ok := sheet.Animation().FindClip(test.name)
+		if !ok {
+			t.Errorf("%s: clip is missing", test.name)
+			continue
+		}
+
+		positions := make([]float64, 0)
+		for _, frame := range clip.Frames {
+			positions = append(positions, frame.Source.Position.X)
+		}
+
+		if clip.Mode != test.mode || !slices.Equal(positions, test.positions) {
+			t.Errorf("%s: mode %d, frames at %v, want mode %d, frames at %v", test.name, clip.Mode, positions, test.mode, test.positions)
+		}
+	}
+
+	// Tags outside of the frames are skipped
+	if _, ok := sheet.Animation().FindClip("broken"); ok {
+		t.Errorf("tag outside of the frames was loaded")
+	}
+
+	// The durations are turned from milliseconds into steps
+	clip, _ := sheet.Animation().FindClip("forward")
+
+	if clip.Frames[0].Duration != 6 || clip.Frames[1].Duration != 3 {
+		t.Errorf("durations = %v and %v, want 6 and 3", clip.Frames[0].Duration, clip.Frames[1].Duration)
+	}
+}
@@ -0,0 +1,279 @@
+package gfx
+
+import (
+	"encoding/json"
+	"image"
+	_ "image/png"
+	"log"
+	"os"
+	"sync"
+
+	// Ebitengine
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Texture loaded by the asset manager
+type textureAsset struct {
+	// Decoded image, which is turned into a texture the first time it is used
+	decoded image.Image
+	texture *ebiten.Image
+
+	// Number of sprites using the texture
+	references int
+}
+
+// Loads each texture once by its path, and unloads it when no sprite uses it anymore
+type AssetManager struct {
+	textures map[string]*textureAsset
+
+	// Textures which are being decoded in the background, by their paths
+	// The channel is closed once the texture is decoded
+	pending map[string]chan struct{}
+
+	// Number of textures queued for loading in the background, and the number which have finished
+	queued, finished int
+
+	// Textures are decoded in the background, so the asset manager is locked while it is changed
+	mutex sync.Mutex
+
+	// Decodes the image files, and turns the images into textures and back
+	// These are replaced in the tests, which run without a graphics device
+	decode     func(path string) (image.Image, error)
+	upload     func(decoded image.Image) *ebiten.Image
+	deallocate func(texture *ebiten.Image)
+}
+
+// List of the assets to load before they are needed
+type AssetManifest struct {
+	Textures []string `json:"textures"`
+}
+
+// Shared asset manager of the game
+var Assets = NewAssetManager()
+
+// Creates a new asset manager without any assets
+func NewAssetManager() *AssetManager {
+	assets := &AssetManager{}
+
+	assets.textures = make(map[string]*textureAsset)
+	assets.pending = make(map[string]chan struct{})
+	assets.queued = 0
+	assets.finished = 0
+
+	assets.decode = decodeImage
+	assets.upload = ebiten.NewImageFromImage
+	assets.deallocate = (*ebiten.Image).Deallocate
+
+	return assets
+}
+
+// Returns the texture at the path, and loads it if it has not been loaded yet
+// Each call is a reference to the texture, which has to be released
+func (assets *AssetManager) Texture(path string) *ebiten.Image {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	asset := assets.load(path)
+	asset.references++
+
+	return asset.texture
+}
+
+// Removes a reference to the texture at the path
+// The texture is unloaded once nothing references it
+func (assets *AssetManager) Release(path string) {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	asset, ok := assets.textures[path]
+	if !ok || asset.references == 0 {
+		return
+	}
+
+	asset.references--
+
+	if asset.references == 0 {
+		assets.unload(path)
+	}
+}
+
+// Returns the number of references to the texture at the path
+func (assets *AssetManager) References(path string) int {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	if asset, ok := assets.textures[path]; ok {
+		return asset.references
+	}
+
+	return 0
+}
+
+// Returns true if the texture at the path is loaded
+func (assets *AssetManager) Loaded(path string) bool {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	_, ok := assets.textures[path]
+
+	return ok
+}
+
+// Loads the textures now, so they are ready before they are used
+// Preloaded textures stay loaded until they are referenced and released, or until the unused textures are unloaded
+func (assets *AssetManager) Preload(paths []string) {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	for _, path := range paths {
+		assets.load(path)
+	}
+}
+
+// Decodes the textures in the background
+// The progress of the loading can be checked every frame
+func (assets *AssetManager) PreloadAsync(paths []string) {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	assets.queued += len(paths)
+
+	for _, path := range paths {
+		// Textures which are loaded, or already being decoded, are not decoded again
+		_, loaded := assets.textures[path]
+		_, decoding := assets.pending[path]
+
+		if loaded || decoding {
+			assets.finished++
+			continue
+		}
+
+		done := make(chan struct{})
+		assets.pending[path] = done
+
+		go func() {
+			decoded, err := assets.decode(path)
+
+			assets.mutex.Lock()
+			defer assets.mutex.Unlock()
+
+			assets.finished++
+			delete(assets.pending, path)
+			close(done)
+
+			// Textures which failed to decode are loaded again when they are used, which reports the error
+			if err != nil {
+				return
+			}
+
+			assets.textures[path] = &textureAsset{decoded: decoded}
+		}()
+	}
+}
+
+// Loads the textures of the manifest file in the background
+func (assets *AssetManager) LoadManifest(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var manifest AssetManifest
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return err
+	}
+
+	assets.PreloadAsync(manifest.Textures)
+
+	return nil
+}
+
+// Returns the fraction of the background loading which has finished (ranges from 0.0 to 1.0)
+func (assets *AssetManager) Progress() float64 {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	if assets.queued == 0 {
+		return 1
+	}
+
+	return float64(assets.finished) / float64(assets.queued)
+}
+
+// Unloads every texture which is not referenced, like preloaded textures which were never used
+func (assets *AssetManager) UnloadUnused() {
+	assets.mutex.Lock()
+	defer assets.mutex.Unlock()
+
+	for path, asset := range assets.textures {
+		if asset.references == 0 {
+			assets.unload(path)
+		}
+	}
+}
+
+// Returns the loaded texture at the path, and loads it if needed
+// Textures which are being decoded in the background are waited for, instead of being decoded twice
+// The asset manager MUST be locked, and it is unlocked while waiting
+func (assets *AssetManager) load(path string) *textureAsset {
+	for {
+		done, ok := assets.pending[path]
+		if !ok {
+			break
+		}
+
+		assets.mutex.Unlock()
+		<-done
+		assets.mutex.Lock()
+	}
+
+	asset, ok := assets.textures[path]
+
+	if !ok {
+		decoded, err := assets.decode(path)
+		if err != nil {
+			log.Fatal(err)
+			panic("Texture was not properly loaded. Image path: " + path)
+		}
+
+		asset = &textureAsset{decoded: decoded}
+		assets.textures[path] = asset
+	}
+
+	// Decoded images are turned into textures when they are first used
+	if asset.texture == nil {
+		asset.texture = assets.upload(asset.decoded)
+		asset.decoded = nil
+	}
+
+	return asset
+}
+
+// Removes the texture at the path
+// The asset manager MUST be locked
+func (assets *AssetManager) unload(path string) {
+	asset, ok := assets.textures[path]
+	if !ok {
+		return
+	}
+
+	if asset.texture != nil {
+		assets.deallocate(asset.texture)
+	}
+
+	delete(assets.textures, path)
+}
+
+// Decodes the image file at the path
+func decodeImage(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoded, _, err := image.Decode(file)
+
+	return decoded, err
+}
@@ -0,0 +1,180 @@
+package gfx
+
+import (
+	"image"
+	"sync"
+	"testing"
+
+	// Ebitengine
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Loads textures without image files or a graphics device
+// Decoding can be held back, to check the loading in the background
+type fakeLoader struct {
+	mutex sync.Mutex
+
+	// Number of times each path was decoded
+	decoded map[string]int
+
+	// Textures which were deallocated
+	deallocated []*ebiten.Image
+
+	// Decoding waits until the gate is closed
+	gate chan struct{}
+}
+
+func newFakeAssets() (*AssetManager, *fakeLoader) {
+	loader := &fakeLoader{}
+	loader.decoded = make(map[string]int)
+	loader.gate = make(chan struct{})
+
+	// Decoding is not held back unless a test asks for it
+	close(loader.gate)
+
+	assets := NewAssetManager()
+
+	assets.decode = func(path string) (image.Image, error) {
+		<-loader.gate
+
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+
+		loader.decoded[path]++
+
+		return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
+	}
+
+	// Textures are only compared, so they are never used by Ebitengine
+	assets.upload = func(decoded image.Image) *ebiten.Image {
+		return new(ebiten.Image)
+	}
+
+	assets.deallocate = func(texture *ebiten.Image) {
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+
+		loader.deallocated = append(loader.deallocated, texture)
+	}
+
+	return assets, loader
+}
+
+func (loader *fakeLoader) decodes(path string) int {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	return loader.decoded[path]
+}
+
+func TestTextureReferences(t *testing.T) {
+	assets, loader := newFakeAssets()
+
+	first := assets.Texture("a.png")
+	second := assets.Texture("a.png")
+
+	if first != second {
+		t.Errorf("the same path returned different textures")
+	}
+
+	if got := loader.decodes("a.png"); got != 1 {
+		t.Errorf("decoded %d times, want 1", got)
+	}
+
+	if got := assets.References("a.png"); got != 2 {
+		t.Errorf("references = %d, want 2", got)
+	}
+
+	// The texture stays loaded while it is referenced
+	assets.Release("a.png")
+
+	if !assets.Loaded("a.png") || assets.References("a.png") != 1 {
+		t.Errorf("released once: loaded = %v, references = %d, want true and 1", assets.Loaded("a.png"), assets.References("a.png"))
+	}
+
+	// The last release unloads the texture
+	assets.Release("a.png")
+
+	if assets.Loaded("a.png") {
+		t.Errorf("texture is still loaded without any references")
+	}
+
+	if len(loader.deallocated) != 1 || loader.deallocated[0] != first {
+		t.Errorf("deallocated %v, want only the texture", loader.deallocated)
+	}
+
+	// Releasing a texture which is not loaded does nothing
+	assets.Release("a.png")
+
+	if got := assets.References("a.png"); got != 0 {
+		t.Errorf("references after releasing an unloaded texture = %d, want 0", got)
+	}
+
+	// Loading it again decodes it again
+	assets.Texture("a.png")
+
+	if got := loader.decodes("a.png"); got != 2 {
+		t.Errorf("decoded %d times after reloading, want 2", got)
+	}
+}
+
+func TestPreloadAsync(t *testing.T) {
+	assets, loader := newFakeAssets()
+
+	// Hold back the decoding
+	loader.gate = make(chan struct{})
+
+	if got := assets.Progress(); got != 1 {
+		t.Errorf("progress without anything queued = %v, want 1", got)
+	}
+
+	assets.PreloadAsync([]string{"a.png", "b.png"})
+
+	if got := assets.Progress(); got != 0 {
+		t.Errorf("progress while decoding = %v, want 0", got)
+	}
+
+	// Queuing a texture which is being decoded does not decode it twice
+	assets.PreloadAsync([]string{"a.png"})
+
+	if got := assets.Progress(); got != 1.0/3 {
+		t.Errorf("progress after queuing a texture twice = %v, want 1/3", got)
+	}
+
+	close(loader.gate)
+
+	// Using the textures waits for them to be decoded
+	assets.Texture("a.png")
+	assets.Texture("b.png")
+
+	for _, path := range []string{"a.png", "b.png"} {
+		if got := loader.decodes(path); got != 1 {
+			t.Errorf("%s decoded %d times, want 1", path, got)
+		}
+	}
+
+	if got := assets.Progress(); got != 1 {
+		t.Errorf("progress after decoding = %v, want 1", got)
+	}
+}
+
+func TestUnloadUnused(t *testing.T) {
+	assets, loader := newFakeAssets()
+
+	assets.Preload([]string{"a.png", "b.png"})
+	texture := assets.Texture("a.png")
+
+	assets.UnloadUnused()
+
+	if !assets.Loaded("a.png") {
+		t.Errorf("referenced texture was unloaded")
+	}
+
+	if assets.Loaded("b.png") {
+		t.Errorf("unused texture is still loaded")
+	}
+
+	if len(loader.deallocated) != 1 || loader.deallocated[0] == texture {
+		t.Errorf("deallocated %v, want only the unused texture", loader.deallocated)
+	}
+}
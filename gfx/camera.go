@@ -0,0 +1,194 @@
+package gfx
+
+import (
+	"math/rand/v2"
+
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+// View of the world which is drawn onto the screen
+type Camera struct {
+	// Center of the view in the world
+	Position physics.Vector2f
+
+	// Size of the screen
+	Viewport physics.Vector2f
+
+	// Point the camera moves towards
+	Target physics.Vector2f
+
+	// Fraction of the distance to the target moved each step (ranges from 0.0 to 1.0)
+	Smoothing float64
+
+	// Size of the box around the center of the view, which the target moves in without moving the camera
+	Deadzone physics.Vector2f
+
+	// How far ahead of the target the camera looks for each unit of speed, and the farthest it looks ahead
+	LookAhead    float64
+	MaxLookAhead float64
+
+	// Area the view stays inside of
+	// The view is not limited if the bounds have no size
+	Bounds physics.Body
+
+	// Scale of the world on the screen
+	Zoom float64
+
+	// Amount of shaking, which wears off each step (ranges from 0.0 to 1.0)
+	Trauma      float64
+	TraumaDecay float64
+
+	// Farthest the view is moved and turned, in pixels and radians, at full trauma
+	MaxShake      float64
+	MaxShakeAngle float64
+
+	// Shake of the current step
+	shakeOffset physics.Vector2f
+	shakeAngle  float64
+
+	// Random numbers for the shaking, which are not shared with the game logic
+	random *rand.Rand
+}
+
+// Creates a new camera with the default values, which covers the screen
+func NewCamera(viewport physics.Vector2f) Camera {
+	camera := Camera{}
+
+	camera.Position = viewport.Scale(0.5)
+	camera.Viewport = viewport
+	camera.Target = camera.Position
+	camera.Smoothing = 0.1
+	camera.Deadzone = physics.NewVector2f(32, 24)
+	camera.LookAhead = 16
+	camera.MaxLookAhead = 64
+	camera.Bounds = physics.NewBody(physics.NewVector2f(0, 0), physics.NewVector2f(0, 0))
+	camera.Zoom = 1
+	camera.Trauma = 0
+	camera.TraumaDecay = 0.02
+	camera.MaxShake = 8
+	camera.MaxShakeAngle = 0.05
+	camera.random = rand.New(rand.NewPCG(1, 2))
+
+	return camera
+}
+
+// Moves the target to the point, and ahead of it in the direction of the velocity
+func (camera *Camera) Follow(point, velocity physics.Vector2f) {
+	ahead := velocity.Scale(camera.LookAhead)
+
+	if ahead.Magnitude() > camera.MaxLookAhead {
+		ahead = ahead.Normalize().Scale(camera.MaxLookAhead)
+	}
+
+	camera.Target = point.Add(ahead)
+}
+
+// Moves the view to the target at once
+func (camera *Camera) Snap() {
+	camera.Position = camera.Target
+	camera.clamp()
+}
+
+// Adds to the shaking, up to full trauma
+func (camera *Camera) AddTrauma(amount float64) {
+	camera.Trauma = min(1, camera.Trauma+amount)
+}
+
+// Moves the view towards the target, and shakes it
+func (camera *Camera) Update() {
+	// The view only moves once the target leaves the deadzone
+	goal := camera.Position
+	half := camera.Deadzone.Scale(0.5 / camera.Zoom)
+	delta := camera.Target.Sub(camera.Position)
+
+	if delta.X > half.X {
+		goal.X = camera.Target.X - half.X
+	} else if delta.X < -half.X {
+		goal.X = camera.Target.X + half.X
+	}
+
+	if delta.Y > half.Y {
+		goal.Y = camera.Target.Y - half.Y
+	} else if delta.Y < -half.Y {
+		goal.Y = camera.Target.Y + half.Y
+	}
+
+	camera.Position = camera.Position.Lerp(goal, camera.Smoothing)
+	camera.clamp()
+
+	// The shaking grows with the square of the trauma, so small hits barely shake
+	shake := camera.Trauma * camera.Trauma
+
+	camera.shakeOffset = physics.NewVector2f(
+		camera.MaxShake*shake*(camera.random.Float64()*2-1),
+		camera.MaxShake*shake*(camera.random.Float64()*2-1),
+	)
+	camera.shakeAngle = camera.MaxShakeAngle * shake * (camera.random.Float64()*2 - 1)
+
+	camera.Trauma = max(0, camera.Trauma-camera.TraumaDecay)
+}
+
+// Keeps the view inside the bounds
+// Bounds smaller than the view are centered
+func (camera *Camera) clamp() {
+	if camera.Bounds.Size.X <= 0 || camera.Bounds.Size.Y <= 0 {
+		return
+	}
+
+	half := camera.Viewport.Scale(0.5 / camera.Zoom)
+	minimum := camera.Bounds.Min().Add(half)
+	maximum := camera.Bounds.Max().Sub(half)
+
+	if minimum.X > maximum.X {
+		camera.Position.X = camera.Bounds.Center().X
+	} else {
+		camera.Position.X = min(maximum.X, max(minimum.X, camera.Position.X))
+	}
+
+	if minimum.Y > maximum.Y {
+		camera.Position.Y = camera.Bounds.Center().Y
+	} else {
+		camera.Position.Y = min(maximum.Y, max(minimum.Y, camera.Position.Y))
+	}
+}
+
+// Returns the transform from the world to the screen
+func (camera Camera) View() physics.Transform {
+	center := camera.Viewport.Scale(0.5).Add(camera.shakeOffset)
+
+	return physics.NewTranslation(center).
+		Compose(physics.NewRotation(camera.shakeAngle)).
+		Compose(physics.NewScaling(physics.NewVector2f(camera.Zoom, camera.Zoom))).
+		Compose(physics.NewTranslation(camera.Position.Neg()))
+}
+
+// Returns where the point in the world is on the screen
+func (camera Camera) WorldToScreen(point physics.Vector2f) physics.Vector2f {
+	return camera.View().Apply(point)
+}
+
+// Returns where the point on the screen is in the world
+func (camera Camera) ScreenToWorld(point physics.Vector2f) physics.Vector2f {
+	inverse, ok := camera.View().Invert()
+	if !ok {
+		return point
+	}
+
+	return inverse.Apply(point)
+}
+
+// Returns the area of the world which is on the screen, without the shaking
+func (camera Camera) VisibleArea() physics.Body {
+	half := camera.Viewport.Scale(0.5 / camera.Zoom)
+
+	return physics.NewBodyFromCorners(camera.Position.Sub(half), camera.Position.Add(half))
+}
+
+// Transform from the world to the screen, which every render function draws through
+var view = physics.NewTransform()
+
+// Draws through the view of the camera
+func SetView(camera Camera) {
+	view = camera.View()
+}
@@ -0,0 +1,31 @@
+package gfx
+
+import (
+	"image/color"
+
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+// Width of the outlines in pixels
+const outlineWidth = 1
+
+// The outlines are drawn through the view of the camera, and keep their width when zoomed
+func RenderLine(color color.RGBA, start, end physics.Vector2f) {
+	primitives.addLine(color, view.Apply(start), view.Apply(end), outlineWidth)
+}
+
+func RenderRectangleOutline(color color.RGBA, body physics.Body) {
+	RenderPolygonOutline(color, corners(body))
+}
+
+func RenderCircleOutline(color color.RGBA, center physics.Vector2f, radius float64) {
+	RenderPolygonOutline(color, circlePoints(center, radius))
+}
+
+// Draws the edges between the points, and from the last point back to the first
+func RenderPolygonOutline(color color.RGBA, points []physics.Vector2f) {
+	for i, point := range points {
+		RenderLine(color, point, points[(i+1)%len(points)])
+	}
+}
@@ -0,0 +1,157 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	// Ebitengine
+	"github.com/hajimehoshi/ebiten/v2"
+
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+// Number of segments of a circle
+const circleSegments = 24
+
+// Most indices which are drawn with one draw call, rounded down to whole triangles
+const maxIndices = (1 << 16) / 3 * 3
+
+// Shared white texture, which every primitive is drawn with
+// The center pixel is used, so the edges of the texture are never sampled
+var (
+	whiteImage    = ebiten.NewImage(3, 3)
+	whiteSubImage = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	whiteImage.Fill(color.White)
+}
+
+// Triangles which are drawn with one draw call
+type primitiveBatch struct {
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// Primitives of the current frame which have not been drawn yet
+var primitives primitiveBatch
+
+// Draws the batched primitives onto the screen
+// This MUST be called before anything else is drawn, and at the end of the frame
+func Flush() {
+	primitives.flush()
+}
+
+// Draws the triangles of the batch onto the screen, and empties it
+func (batch *primitiveBatch) flush() {
+	if len(batch.indices) == 0 {
+		return
+	}
+
+	screen.DrawTriangles(batch.vertices, batch.indices, whiteSubImage, &ebiten.DrawTrianglesOptions{})
+
+	batch.vertices = batch.vertices[:0]
+	batch.indices = batch.indices[:0]
+}
+
+// Adds a filled convex polygon with points on the screen to the batch
+func (batch *primitiveBatch) addPolygon(color color.RGBA, points []physics.Vector2f) {
+	if len(points) < 3 {
+		return
+	}
+
+	// Indices can only point to the first vertices
+	if len(batch.vertices)+len(points) > math.MaxUint16 || len(batch.indices)+(len(points)-2)*3 > maxIndices {
+		batch.flush()
+	}
+
+	first := uint16(len(batch.vertices))
+
+	for _, point := range points {
+		batch.vertices = append(batch.vertices, ebiten.Vertex{
+			DstX:   float32(point.X),
+			DstY:   float32(point.Y),
+			SrcX:   1,
+			SrcY:   1,
+			ColorR: float32(color.R) / 255,
+			ColorG: float32(color.G) / 255,
+			ColorB: float32(color.B) / 255,
+			ColorA: float32(color.A) / 255,
+		})
+	}
+
+	// Fan out from the first point
+	for i := 1; i+1 < len(points); i++ {
+		batch.indices = append(batch.indices, first, first+uint16(i), first+uint16(i+1))
+	}
+}
+
+// Adds a line with points on the screen to the batch
+func (batch *primitiveBatch) addLine(color color.RGBA, start, end physics.Vector2f, width float64) {
+	direction := end.Sub(start)
+
+	if direction.Magnitude() == 0 {
+		return
+	}
+
+	// Half of the width on each side of the line
+	side := direction.Normalize().Rotate(math.Pi / 2).Scale(width / 2)
+
+	batch.addPolygon(color, []physics.Vector2f{
+		start.Add(side),
+		end.Add(side),
+		end.Sub(side),
+		start.Sub(side),
+	})
+}
+
+// Returns the points of the transformed shape
+func transformPoints(transform physics.Transform, points []physics.Vector2f) []physics.Vector2f {
+	transformed := make([]physics.Vector2f, len(points))
+
+	for i, point := range points {
+		transformed[i] = transform.Apply(point)
+	}
+
+	return transformed
+}
+
+// Returns the corners of the body, clockwise from the top left
+func corners(body physics.Body) []physics.Vector2f {
+	return []physics.Vector2f{
+		body.Position,
+		physics.NewVector2f(body.Position.X+body.Size.X, body.Position.Y),
+		body.Position.Add(body.Size),
+		physics.NewVector2f(body.Position.X, body.Position.Y+body.Size.Y),
+	}
+}
+
+// Returns the points around the circle
+func circlePoints(center physics.Vector2f, radius float64) []physics.Vector2f {
+	points := make([]physics.Vector2f, circleSegments)
+
+	for i := range points {
+		angle := float64(i) / circleSegments * 2 * math.Pi
+		points[i] = center.Add(physics.NewVector2f(math.Cos(angle), math.Sin(angle)).Scale(radius))
+	}
+
+	return points
+}
+
+// The unit for the rotation is in radians, and the rectangle is rotated around its center
+func RenderRectangle(color color.RGBA, destinationBody physics.Body, rotation float64) {
+	transform := view.Compose(physics.NewRotationAround(destinationBody.Center(), rotation))
+
+	primitives.addPolygon(color, transformPoints(transform, corners(destinationBody)))
+}
+
+func RenderCircle(color color.RGBA, center physics.Vector2f, radius float64) {
+	primitives.addPolygon(color, transformPoints(view, circlePoints(center, radius)))
+}
+
+// The polygon has to be convex
+func RenderPolygon(color color.RGBA, points []physics.Vector2f) {
+	primitives.addPolygon(color, transformPoints(view, points))
+}
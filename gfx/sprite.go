@@ -20,11 +20,17 @@ type Sprite struct {
 	// The rotation of the sprite in radians
 	Rotation float64
 
+	// The texture is mirrored from left to right
+	FlipX bool
+
 	// The source co-ordinates and size
 	Source physics.Body
 
 	// The destination co-ordinates and size
 	Destination physics.Body
+
+	// The path of the texture in the asset manager, which is released when the sprite is destroyed
+	Path string
 }
 
 func NewSprite(texture *ebiten.Image) Sprite {
@@ -38,23 +44,31 @@ func NewSprite(texture *ebiten.Image) Sprite {
 
 	// Set the rotation in radians
 	sprite.Rotation = 0
+	sprite.FlipX = false
 
 	// Set the default position and size values
 	// The sizes will be the size of the image
-	sprite.Source = physics.NewBody(
-		physics.NewVector2f(0, 0),
-		physics.NewVector2f(
-			float64(sprite.Image.Bounds().Dx()),
-			float64(sprite.Image.Bounds().Dy()),
-		),
-	)
-	sprite.Destination = physics.NewBody(
-		physics.NewVector2f(0, 0),
-		physics.NewVector2f(
+	// Sprites without a texture have no size until it is set
+	size := physics.NewVector2f(0, 0)
+
+	if sprite.Image != nil {
+		size = physics.NewVector2f(
 			float64(sprite.Image.Bounds().Dx()),
 			float64(sprite.Image.Bounds().Dy()),
-		),
-	)
+		)
+	}
+
+	sprite.Source = physics.NewBody(physics.NewVector2f(0, 0), size)
+	sprite.Destination = physics.NewBody(physics.NewVector2f(0, 0), size)
+
+	return sprite
+}
+
+// Creates a sprite with the texture at the path from the asset manager
+func NewSpriteFromPath(path string) Sprite {
+	sprite := NewSprite(Assets.Texture(path))
+
+	sprite.Path = path
 
 	return sprite
 }
@@ -65,10 +79,14 @@ func (sprite *Sprite) Render() {
 		RenderRectangle(sprite.Color, sprite.Destination, sprite.Rotation)
 	} else {
 		// Draw the rectangle with the texture
-		RenderTexture(sprite.Image, sprite.Source, sprite.Destination, sprite.Rotation)
+		RenderTexture(sprite.Image, sprite.Source, sprite.Destination, sprite.Rotation, sprite.FlipX)
 	}
 }
 
 func (sprite *Sprite) Destroy() {
-	// TODO: Unload texture
+	// Release the texture, so it is unloaded once no sprite uses it
+	if sprite.Path != "" {
+		Assets.Release(sprite.Path)
+		sprite.Path = ""
+	}
 }
@@ -2,7 +2,6 @@ package gfx
 
 import (
 	"image"
-	"image/color"
 	"log"
 
 	// Ebitengine
@@ -25,6 +24,8 @@ func GetScreen() **ebiten.Image {
 	return &screen
 }
 
+// Loads the texture at the path from the disk
+// Use the asset manager instead, which only loads each texture once
 func NewTexture(path string) *ebiten.Image {
 	// Load an image
 	texture, _, err := ebitenutil.NewImageFromFile(path)
@@ -36,43 +37,40 @@ func NewTexture(path string) *ebiten.Image {
 	return texture
 }
 
-func RenderRectangle(color color.RGBA, destinationBody physics.Body, rotation float64) {
-	// Options provided by Ebitengine for drawing
-	// The order is important for all scales and transformations!
-	options := &ebiten.DrawImageOptions{}
-
-	// Apply the position and the size
-	// Assuming that the original is a 1x1 rectangle
-	options.GeoM.Scale(destinationBody.Size.X, destinationBody.Size.Y)
-	options.GeoM.Translate(destinationBody.Position.X, destinationBody.Position.Y)
-
-	// Rotating the image
-	// Don't rotate for the angles which are multiples of 360 as rotations are computationally expensive
-	if int(rotation)%360 != 0 {
-		// Get the center of the image
-		center := destinationBody.Center()
+// Returns the Ebitengine matrix of the transform
+func geoM(transform physics.Transform) ebiten.GeoM {
+	var matrix ebiten.GeoM
 
-		// Translate to the center of the image
-		options.GeoM.Translate(-center.X, -center.Y)
+	matrix.SetElement(0, 0, transform.A)
+	matrix.SetElement(0, 1, transform.C)
+	matrix.SetElement(0, 2, transform.E)
+	matrix.SetElement(1, 0, transform.B)
+	matrix.SetElement(1, 1, transform.D)
+	matrix.SetElement(1, 2, transform.F)
 
-		// Rotate around the center
-		// The unit for the rotation is in radians
-		options.GeoM.Rotate(rotation)
+	return matrix
+}
 
-		// Translate back to the screen position
-		options.GeoM.Translate(center.X, center.Y)
+// Returns the transform which stretches an image of the size over the destination body
+// The image is rotated around the center of the destination body, and the unit for the rotation is in radians
+// Flipped images are mirrored from left to right
+func destinationTransform(size physics.Vector2f, destinationBody physics.Body, rotation float64, flipX bool) physics.Transform {
+	// Apply the size, and then the position
+	scale := physics.NewVector2f(destinationBody.Size.X/size.X, destinationBody.Size.Y/size.Y)
+	placement := physics.NewTranslation(destinationBody.Position).Compose(physics.NewScaling(scale))
+
+	// Mirror the image inside of its own size
+	if flipX {
+		placement = placement.Compose(physics.NewTranslation(physics.NewVector2f(size.X, 0))).
+			Compose(physics.NewScaling(physics.NewVector2f(-1, 1)))
 	}
 
-	// Draw the rectangle with the said color
-	coloredTexture := ebiten.NewImage(1, 1)
-	coloredTexture.Fill(color)
-
-	screen.DrawImage(coloredTexture, options)
+	return physics.NewRotationAround(destinationBody.Center(), rotation).Compose(placement)
 }
 
 func RenderTexture(texture *ebiten.Image,
 	sourceBody, destinationBody physics.Body,
-	rotation float64,
+	rotation float64, flipX bool,
 ) {
 	// Crop the texture
 	sourceRectangle := image.Rect(
@@ -83,34 +81,14 @@ func RenderTexture(texture *ebiten.Image,
 
 	subImage := texture.SubImage(sourceRectangle).(*ebiten.Image)
 
+	// Draw the batched primitives first, so they stay under the texture
+	Flush()
+
 	// Options provided by Ebitengine for drawing
-	// The order is important for all scales and transformations!
 	options := &ebiten.DrawImageOptions{}
 
-	// Apply the position and the size
-	// The division shrinks the image to a 1x1 rectanlge
-	options.GeoM.Scale(
-		destinationBody.Size.X/sourceBody.Size.X,
-		destinationBody.Size.Y/sourceBody.Size.Y,
-	)
-	options.GeoM.Translate(destinationBody.Position.X, destinationBody.Position.Y)
-
-	// Rotating the image
-	// Don't rotate for the angles which are multiples of 360 as rotations are computationally expensive
-	if int(rotation)%360 != 0 {
-		// Get the center of the image
-		center := destinationBody.Center()
-
-		// Translate to the center of the image
-		options.GeoM.Translate(-center.X, -center.Y)
-
-		// Rotate around the center
-		// The unit for the rotation is in radians
-		options.GeoM.Rotate(rotation)
-
-		// Translate back to the screen position
-		options.GeoM.Translate(center.X, center.Y)
-	}
+	// Stretch the cropped texture over the destination, and then view it through the camera
+	options.GeoM = geoM(view.Compose(destinationTransform(sourceBody.Size, destinationBody, rotation, flipX)))
 
 	// Render the image
 	screen.DrawImage(subImage, options)
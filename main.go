@@ -1,7 +1,21 @@
 package main
 
+import (
+	"flag"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/physics"
+)
+
 func main() {
+	// Snap the physics onto a grid and record the checksum of each step, so runs can be compared
+	deterministic := flag.Bool("deterministic", false, "run the physics in determinism mode")
+	flag.Parse()
+
 	// Create a window
 	game := NewGame(800, 450, "Game")
+	ecs.GetResource[physics.PhysicsSettings](&game.Manager).Deterministic = *deterministic
+
 	game.Run()
 }
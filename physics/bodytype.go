@@ -0,0 +1,85 @@
+package physics
+
+const (
+	// How a body is moved
+	// Static bodies never move, and are baked into the physics world
+	// Kinematic bodies are moved by code, push the dynamic bodies, and ignore gravity
+	// Dynamic bodies are fully simulated
+	BodyStatic int = iota
+	BodyKinematic
+	BodyDynamic
+)
+
+// How the physics step moves a body
+type BodyType struct {
+	Type int
+
+	// Dynamic bodies which have come to rest are skipped by the physics step
+	Sleeping bool
+
+	// Number of steps the body has been at rest for
+	RestTime int
+}
+
+// Creates a new body type
+func NewBodyType(kind int) BodyType {
+	bodyType := BodyType{}
+
+	bodyType.Type = kind
+	bodyType.Sleeping = false
+	bodyType.RestTime = 0
+
+	return bodyType
+}
+
+// Counts how long the body has been at rest, and puts it to sleep once it has rested for long enough
+// This MUST be handled AFTER the collisions, while the velocity is still the movement of the step
+func (bodyType *BodyType) UpdateSleep(force *Force, settings PhysicsSettings) {
+	if bodyType.Type != BodyDynamic {
+		return
+	}
+
+	// Only bodies resting on the ground can sleep
+	if force.Collisions.Grounded(force.Gravity) && force.Velocity.Magnitude() < settings.SleepSpeed {
+		bodyType.RestTime += 1
+	} else {
+		bodyType.RestTime = 0
+	}
+
+	if bodyType.RestTime >= settings.SleepTime {
+		bodyType.Sleeping = true
+
+		// The body stays still while it sleeps
+		force.Acceleration = NewVector2f(0, 0)
+	}
+}
+
+// Wakes the body up, so it is simulated again
+func (bodyType *BodyType) Wake() {
+	bodyType.Sleeping = false
+	bodyType.RestTime = 0
+}
+
+// Returns true if the body has been pushed or moved since it fell asleep
+func (force Force) Disturbed() bool {
+	zero := NewVector2f(0, 0)
+
+	return force.Acceleration != zero || force.Velocity != zero || force.Push != zero
+}
+
+// Returns true if one of the colliders is right under the body, on the side the gravity pulls it to
+func (body Body) Supported(colliders []Collider, gravity Vector2f) bool {
+	turns := gravityTurns(gravity)
+	body = body.rotateQuarter(turns)
+
+	// Probe one pixel below the feet
+	feet := NewBody(NewVector2f(body.Position.X, body.Position.Y+body.Size.Y), NewVector2f(body.Size.X, 1))
+
+	for _, collider := range rotateColliders(colliders, NewVector2f(0, 0), turns) {
+		if feet.CollidesWithStaticBody(collider.Body) {
+			return true
+		}
+	}
+
+	return false
+}
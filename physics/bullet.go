@@ -0,0 +1,124 @@
+package physics
+
+import (
+	"math"
+)
+
+// Fast moving body which stops where it first hits a collider
+// The whole step is swept, so bullets can't pass through thin tiles however fast they move
+type Bullet struct {
+	// Longest distance moved in one substep
+	MaxStep float64
+
+	// The bullet hit a collider during this step
+	Hit bool
+
+	// Entity id of the collider which was hit
+	Id int
+
+	// Fraction of the step travelled before the hit (ranges from 0.0 to 1.0)
+	Time float64
+
+	// Where the bullet touched the collider, and the normal of the surface
+	Point  Vector2f
+	Normal Vector2f
+}
+
+// Creates a new bullet which moves at most max step in each substep
+func NewBullet(maxStep float64) Bullet {
+	bullet := Bullet{}
+
+	bullet.MaxStep = maxStep
+	bullet.Hit = false
+
+	return bullet
+}
+
+// Returns how far the edge of the shape is from its center, in the opposite direction of the normal
+func supportDistance(shape Shape, contactNormal Vector2f) float64 {
+	core, radius := shape.Rounded()
+
+	return math.Abs(contactNormal.X)*core.Size.X/2 + math.Abs(contactNormal.Y)*core.Size.Y/2 + radius
+}
+
+// Moves the body along the velocity until it hits a collider, and records the impact
+// The velocity is split into substeps which are no longer than the max step, and each substep is swept
+func (bullet *Bullet) Sweep(body Body, bodyShape BodyShape, colliders []Collider, force *Force) {
+	bullet.Hit = false
+
+	// Reset the collisions
+	force.Collisions.Reset()
+
+	velocity := force.Velocity
+
+	// Split the velocity into substeps
+	steps := max(1, int(math.Ceil(velocity.Magnitude()/bullet.MaxStep)))
+	step := velocity.Scale(1 / float64(steps))
+
+	for i := range steps {
+		// Where the body is at the start of the substep
+		moved := body.Translate(step.Scale(float64(i)))
+
+		shape := bodyShape.Fit(moved)
+
+		// Find the first collider hit during the substep
+		hitTime := math.Inf(1)
+
+		var contactNormal Vector2f
+		var hitCollider Collider
+
+		for _, collider := range colliders {
+			// Carry out a broad phase to stop handling
+			if !moved.BroadPhase(collider.Body, step) {
+				continue
+			}
+
+			collision, time, normal := collider.VsShape(shape, step)
+
+			if !collision || time >= hitTime {
+				continue
+			}
+
+			// Skip one-way platforms which the body is not landing on
+			if !collider.Blocks(moved, force, normal) {
+				continue
+			}
+
+			hitTime = time
+			contactNormal = normal
+			hitCollider = collider
+		}
+
+		if math.IsInf(hitTime, 1) {
+			continue
+		}
+
+		// Record the impact
+		bullet.Hit = true
+		bullet.Id = hitCollider.Id
+		bullet.Time = (float64(i) + hitTime) / float64(steps)
+		bullet.Normal = contactNormal
+
+		// The point on the edge of the body which touched the collider
+		center := ShapeCenter(shape).Add(step.Scale(hitTime))
+
+		extent := supportDistance(shape, contactNormal)
+		bullet.Point = center.Sub(contactNormal.Scale(extent))
+
+		// The contact is reported for the whole step, not the substep
+		contact := NewContact(moved, hitCollider, step, hitTime, contactNormal)
+		contact.Point = bullet.Point
+		contact.RelativeVelocity = velocity.Sub(hitCollider.Velocity)
+		contact.Penetration = max(0, -contact.RelativeVelocity.Dot(contactNormal)) * (1 - bullet.Time)
+		force.Collisions.Contacts = append(force.Collisions.Contacts, contact)
+
+		// Stop the body at the impact
+		force.Velocity = velocity.Scale(bullet.Time)
+
+		// Update the collision direction
+		force.Collisions.UpdateMaterial(hitCollider.Material, contactNormal, force.Gravity)
+		force.Collisions.Update(contactNormal)
+
+		break
+	}
+}
@@ -0,0 +1,121 @@
+package physics
+
+// Returns true if the body overlaps any of the ladders
+func (body Body) OnLadder(ladders []Collider) bool {
+	for _, ladder := range ladders {
+		if body.CollidesWithStaticBody(ladder.Body) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the top of the highest ladder the body overlaps
+func (body Body) LadderTop(ladders []Collider) float64 {
+	top := body.Position.Y + body.Size.Y
+
+	for _, ladder := range ladders {
+		if body.CollidesWithStaticBody(ladder.Body) {
+			top = min(top, ladder.Body.Position.Y)
+		}
+	}
+
+	return top
+}
+
+// Returns true if no other ladder carries on above the ladder, so it can be stood on
+func (ladder Collider) IsLadderTop(ladders []Collider) bool {
+	// Probe one pixel above the ladder
+	probe := NewBody(NewVector2f(ladder.Body.Position.X, ladder.Body.Position.Y-1), NewVector2f(ladder.Body.Size.X, 1))
+
+	for _, other := range ladders {
+		if other.Id != ladder.Id && probe.CollidesWithStaticBody(other.Body) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the ladders which no other ladder carries on above, so they can be stood on
+// The ladders are put into a grid, so each ladder is only checked against the ladders near it
+func LadderTops(ladders []Collider) []Collider {
+	grid := NewWorld(64)
+
+	for _, ladder := range ladders {
+		grid.Add(ladder)
+	}
+
+	tops := make([]Collider, 0)
+
+	for _, ladder := range ladders {
+		nearby := grid.Nearby(ladder.Body.Expand(NewVector2f(0, 1)), LayerAll)
+
+		if ladder.IsLadderTop(nearby) {
+			tops = append(tops, ladder)
+		}
+	}
+
+	return tops
+}
+
+// Climbs up and down ladders
+// Returns true if the body is climbing, which replaces the movement and jumps of the controller
+func (controller *PlatformerController) climb(body *Body, force *Force, input ControllerInput, ladders []Collider) bool {
+	onLadder := body.OnLadder(ladders)
+
+	// Probe one pixel below the feet, for the ladder the body is standing on
+	feet := NewBody(NewVector2f(body.Position.X, body.Position.Y+body.Size.Y), NewVector2f(body.Size.X, 1))
+	belowLadder := feet.OnLadder(ladders)
+
+	if controller.Climbing {
+		// Let go when leaving the ladder, jumping or climbing down onto the ground
+		if !onLadder || input.JumpPressed || (force.Collisions.Down && input.Down && !belowLadder) {
+			controller.Climbing = false
+
+			// Don't grab the ladder again straight after jumping off it
+			if input.JumpPressed {
+				controller.GrabCooldown = controller.LedgeCooldown
+			}
+		}
+	} else if controller.GrabCooldown == 0 && !input.JumpPressed {
+		// Grab the ladder by holding up on it, or by holding down on top of it
+		controller.Climbing = (input.Up && onLadder) || (input.Down && belowLadder)
+	}
+
+	if !controller.Climbing {
+		return false
+	}
+
+	// Climbing counts as standing on the ground, so the body can jump off the ladder
+	controller.AirTime = 0
+	controller.JumpsLeft = controller.Jumps
+	controller.Jumping = false
+	controller.WallSlide = SideNone
+
+	// Ignore the one-way platforms, so the body can climb down through the top of the ladder
+	force.DropThrough = max(force.DropThrough, 1)
+
+	// Move at the climb speed, and stay still when nothing is held
+	force.Acceleration = NewVector2f(0, 0)
+
+	if input.Up {
+		force.Acceleration.Y -= controller.ClimbSpeed
+	}
+	if input.Down {
+		force.Acceleration.Y += controller.ClimbSpeed
+	}
+	if input.Left {
+		force.Acceleration.X -= controller.ClimbSpeed
+	}
+	if input.Right {
+		force.Acceleration.X += controller.ClimbSpeed
+	}
+
+	// Stop with the feet level with the top of the ladder, so the body stands on it
+	feetY := body.Position.Y + body.Size.Y
+	force.Acceleration.Y = max(force.Acceleration.Y, body.LadderTop(ladders)-feetY)
+
+	return true
+}
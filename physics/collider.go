@@ -0,0 +1,69 @@
+package physics
+
+// A body which moving bodies collide against
+type Collider struct {
+	// Entity id of the collider
+	Id int
+
+	Body Body
+
+	// One-way platforms only collide with bodies landing on them from above
+	OneWay bool
+
+	// Shape of the surface if the collider is sloped
+	Slope Slope
+
+	// Collision layer the collider is on
+	Layer uint32
+
+	// Friction and bounciness of the surface
+	Material PhysicsMaterial
+
+	// Movement of the collider during the step
+	Velocity Vector2f
+}
+
+// Creates a new solid collider
+func NewCollider(body Body) Collider {
+	collider := Collider{}
+
+	collider.Body = body
+	collider.OneWay = false
+	collider.Slope = SlopeNone
+	collider.Layer = LayerTile
+	collider.Material = NewPhysicsMaterial()
+	collider.Velocity = NewVector2f(0, 0)
+
+	return collider
+}
+
+// Returns the collider as a rectangle
+func (collider Collider) box() Collider {
+	collider.Slope = SlopeNone
+
+	return collider
+}
+
+// Returns true if the one-way collider should stop the moving body
+func (collider Collider) Blocks(body Body, force *Force, contactNormal Vector2f) bool {
+	// Solid colliders always block
+	if !collider.OneWay {
+		return true
+	}
+
+	// The body is dropping through the platforms
+	if force.DropThrough > 0 {
+		return false
+	}
+
+	// Platforms can only be landed on from above
+	if contactNormal.Y >= -0.5 {
+		return false
+	}
+
+	// The feet of the body have to be above the platform edge in the previous step
+	// The body has not moved yet, so its position is still from the previous step
+	feet := body.Position.Y + body.Size.Y
+
+	return feet <= collider.Body.Position.Y+collisionEpsilon
+}
@@ -7,6 +7,9 @@ import (
 	"sort"
 )
 
+// Small distance used to absorb floating point errors in collisions
+const collisionEpsilon = 1e-6
+
 const (
 	// Collision Types
 	CollisionNone int = iota
@@ -24,7 +27,7 @@ func (body Body) CollidesWithVector(start Vector2f, movementVector Vector2f) (bo
 	}
 
 	// Set all positions relative to the vector starting from the origin
-	body.Position = NewVector2f(body.Position.X-start.X, body.Position.Y-start.Y)
+	body.Position = body.Position.Sub(start)
 
 	// Check if the line collides with any of the body's edges
 	// All of the co+ordinates where the collisions happen can be easily translated into movement vectors
@@ -169,7 +172,6 @@ func (body Body) CollidesWithVector(start Vector2f, movementVector Vector2f) (bo
 				collisionPoint = NewVector2f(collisionPoints[minimumMagnitudeIndex].X, movementVector.Y)
 			case CollisionTop, CollisionBottom:
 				collisionPoint = NewVector2f(movementVector.X, collisionPoints[minimumMagnitudeIndex].Y)
-				fmt.Println("Point: ", collisionPoint)
 			}
 
 			return collision, collisionPoint, collisionType
@@ -220,32 +222,72 @@ func (bodyA Body) CollidesWithDynamicBody(bodyB Body, velocity Vector2f) (bool,
 	return bodyExpanded.CollidesWithVector(start, velocity)
 }
 
+// Returns true if the moving body hits the other body within this step
+// Where hit time is the fraction of the velocity travelled before the hit (hitTime ranges from 0.0 to 1.0)
+func (bodyA Body) VsBody(bodyB Body, velocity Vector2f) (collision bool, hitTime float64, contactNormal Vector2f) {
+	// A stationary body cannot hit anything
+	if velocity.X == 0 && velocity.Y == 0 {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	// Create an expanded body to test collisions against the center of the moving body
+	var bodyExpanded Body
+	bodyExpanded.Position.X = bodyB.Position.X - bodyA.Size.X/2
+	bodyExpanded.Position.Y = bodyB.Position.Y - bodyA.Size.Y/2
+
+	bodyExpanded.Size.X = bodyA.Size.X + bodyB.Size.X
+	bodyExpanded.Size.Y = bodyA.Size.Y + bodyB.Size.Y
+
+	// Cast the velocity from the center of the body
+	collision, hitTime, contactNormal = bodyExpanded.VsRay(bodyA.Center(), velocity)
+
+	// The hit has to happen during this step
+	// A tiny negative hit time is allowed, so that resting bodies don't sink through floating point errors
+	// A body which starts inside the other body will have a negative hit time, so it never collides with itself
+	if !collision || hitTime < -collisionEpsilon || hitTime >= 1 {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	return true, max(0, hitTime), contactNormal
+}
+
 // Resolves collsions between bodies
-func (bodyA Body) CollidiesWithDynamicBodies(bodies []Body, force *Force) {
+// Bodies which were on the ground are snapped to it, only if the gravity pulls them down
+func (bodyA Body) CollidiesWithDynamicBodies(colliders []Collider, force *Force) {
 	// Slice to store data about body collisions
 	type CollisionData struct {
-		// Position of the body in the bodies slice
+		// Position of the collider in the colliders slice
 		Index int
 
-		// Magnitude of the resultant vector
-		Magnitude float64
+		// Time taken to hit the collider
+		HitTime float64
 	}
 
 	collisionData := make([]CollisionData, 0)
 
+	// Whether the body was standing on the ground in the previous step
+	// Only bodies pulled down are snapped to the ground
+	grounded := force.Collisions.Down && gravityTurns(force.Gravity) == 0
+
+	// Reset the collisions
+	force.Collisions.Reset()
+
+	// Slopes are resolved first, so that the body can walk from a slope onto the tiles next to it
+	boxes := bodyA.CollidesWithSlopes(colliders, force)
+
 	// Check which tiles could collided with the body
-	for i, bodyB := range bodies {
+	for i, collider := range boxes {
 		// Carry out a broad phase to stop handling
 		// Minimize expensive physics on absurd tiles that will never collide with
-		collision := bodyA.BroadPhase(bodyB, force.Velocity)
+		collision := bodyA.BroadPhase(collider.Body, force.Velocity)
 
 		if collision {
 			// Check for collision
-			collision, resolvedVelocity, _ := bodyA.CollidesWithDynamicBody(bodyB, force.Velocity)
+			collision, hitTime, _ := bodyA.VsBody(collider.Body, force.Velocity)
 
 			if collision {
-				// Get the distance from the body to the tile
-				data := CollisionData{i, resolvedVelocity.Magnitude()}
+				// Get the time taken to hit the tile
+				data := CollisionData{i, hitTime}
 
 				// Add to the collided tile list
 				collisionData = append(collisionData, data)
@@ -253,51 +295,73 @@ func (bodyA Body) CollidiesWithDynamicBodies(bodies []Body, force *Force) {
 		}
 	}
 
-	// Sort the tiles by which vector required the least movement
+	// Sort the tiles by which collision happened first
 	// This is a fix to imitate actual physics (and to handle other cases and exceptions)
 	sort.SliceStable(collisionData, func(a, b int) bool {
-		return collisionData[a].Magnitude < collisionData[b].Magnitude
+		return collisionData[a].HitTime < collisionData[b].HitTime
 	})
 
-	// Reset the collisions
-	force.Collisions = Collisions{}
-
 	// Resolve the collisions
 	for _, data := range collisionData {
-		collision, velocityResolve, collisionType := bodyA.CollidesWithDynamicBody(bodies[data.Index], force.Velocity)
+		collider := boxes[data.Index]
 
-		if collision {
-			// Update the collision velocity
-			force.Velocity = velocityResolve
-			fmt.Println(velocityResolve)
+		// The velocity may have changed from the previous collisions, so check again
+		collision, hitTime, contactNormal := bodyA.VsBody(collider.Body, force.Velocity)
 
-			// Update the collision direction
-			switch collisionType {
-			case CollisionLeft:
-				force.Collisions.Update(NewVector2f(1, 0))
-			case CollisionRight:
-				force.Collisions.Update(NewVector2f(-1, 0))
-			case CollisionTop:
-				force.Collisions.Update(NewVector2f(0, -1))
-			case CollisionBottom:
-				force.Collisions.Update(NewVector2f(0, 1))
-			default:
-				force.Collisions.Update(NewVector2f(0, 0))
-			}
+		if !collision {
+			continue
+		}
+
+		// Skip one-way platforms which the body is not landing on
+		if !collider.Blocks(bodyA, force, contactNormal) {
+			continue
+		}
+
+		// Walking off a slope onto the top of a tile is not a wall hit
+		feet := bodyA.Position.Y + bodyA.Size.Y + force.Velocity.Y
+		if force.Collisions.Slope && contactNormal.X != 0 && collider.Body.Position.Y >= feet-collisionEpsilon {
+			continue
+		}
+
+		force.Collisions.Contacts = append(force.Collisions.Contacts, NewContact(bodyA, collider, force.Velocity, hitTime, contactNormal))
+
+		// Stop the velocity at the contact point
+		// Only the part of the velocity going into the collider is removed, so the body can slide along it
+		force.Velocity.X += float64(contactNormal.X * math.Abs(force.Velocity.X) * (1 - hitTime))
+		force.Velocity.Y += float64(contactNormal.Y * math.Abs(force.Velocity.Y) * (1 - hitTime))
+
+		// Update the collision direction
+		force.Collisions.UpdateMaterial(collider.Material, contactNormal, force.Gravity)
+		force.Collisions.Update(contactNormal)
+
+		// Standing on a platform which can be dropped through
+		if collider.OneWay {
+			force.Collisions.Platform = true
 		}
 	}
+
+	// Stay on the ground when walking down slopes
+	if grounded {
+		bodyA.SnapToGround(colliders, force)
+	}
+
+	// Count down the drop through timer
+	force.DropThrough = max(0, force.DropThrough-1)
+}
+
+// Returns the area the body can touch while moving with the velocity
+// The area is grown below the body, so that the ground can still be snapped to
+func (body Body) SweptArea(velocity Vector2f) Body {
+	area := sweptArea(body, velocity)
+	area.Size.Y += snapDistance(velocity)
+
+	return area
 }
 
 // Returns true, if the broad phase body collided with another body
 func (bodyA Body) BroadPhase(bodyB Body, velocity Vector2f) bool {
 	// Calculate the broad phase body
-	var bodyBroadPhase Body
-
-	bodyBroadPhase.Position.X = min(bodyA.Position.X, bodyA.Position.X+velocity.X)
-	bodyBroadPhase.Position.Y = min(bodyA.Position.Y, bodyA.Position.Y+velocity.Y)
-
-	bodyBroadPhase.Size.X = bodyA.Size.X + math.Abs(velocity.X)
-	bodyBroadPhase.Size.Y = bodyA.Size.Y + math.Abs(velocity.Y)
+	bodyBroadPhase := sweptArea(bodyA, velocity)
 
 	return bodyBroadPhase.CollidesWithStaticBody(bodyB)
 }
@@ -0,0 +1,69 @@
+package physics
+
+// Contact between a moving body and a collider during the physics step
+type Contact struct {
+	// Entity ids of the moving body and the collider
+	Id, OtherId int
+
+	// Where the body touched the collider
+	Point Vector2f
+
+	// Normal of the surface of the collider, pointing away from the collider
+	Normal Vector2f
+
+	// How far the body would have gone into the collider if nothing stopped it
+	Penetration float64
+
+	// Movement of the body relative to the collider during the step
+	RelativeVelocity Vector2f
+
+	// Friction and bounciness of the surface
+	Material PhysicsMaterial
+}
+
+// Creates the contact of a body moving with the velocity, which hit the collider at the hit time
+// The entity id of the moving body is not known by the physics step, so it is set by the caller
+func NewContact(body Body, collider Collider, velocity Vector2f, hitTime float64, contactNormal Vector2f) Contact {
+	contact := Contact{}
+
+	contact.OtherId = collider.Id
+	contact.Point = contactPoint(body, velocity, hitTime, contactNormal)
+	contact.Normal = contactNormal
+	contact.RelativeVelocity = velocity.Sub(collider.Velocity)
+	contact.Material = collider.Material
+
+	// The rest of the movement into the surface is stopped
+	contact.Penetration = max(0, -contact.RelativeVelocity.Dot(contactNormal)) * (1 - hitTime)
+
+	return contact
+}
+
+// Returns how hard the body hit the surface
+// Landing sounds and damage can scale with the speed of the impact
+func (contact Contact) ImpactSpeed() float64 {
+	return max(0, -contact.RelativeVelocity.Dot(contact.Normal))
+}
+
+// Returns the point on the edge of the body which touched the collider
+func contactPoint(body Body, velocity Vector2f, hitTime float64, contactNormal Vector2f) Vector2f {
+	// Where the center of the body was at the hit
+	center := body.Center().Add(velocity.Scale(hitTime))
+
+	// The contact is on the edge of the body facing the collider
+	extent := max(contactNormal.X, -contactNormal.X)*body.Size.X/2 + max(contactNormal.Y, -contactNormal.Y)*body.Size.Y/2
+
+	return center.Sub(contactNormal.Scale(extent))
+}
+
+// Returns the contact of a body which is resting on, or pushed onto, the surface of a collider
+func surfaceContact(body Body, collider Collider, velocity Vector2f, surface float64, contactNormal Vector2f) Contact {
+	contact := Contact{}
+
+	contact.OtherId = collider.Id
+	contact.Point = NewVector2f(body.Center().X+velocity.X, surface)
+	contact.Normal = contactNormal
+	contact.RelativeVelocity = velocity.Sub(collider.Velocity)
+	contact.Material = collider.Material
+
+	return contact
+}
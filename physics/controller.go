@@ -0,0 +1,476 @@
+package physics
+
+import (
+	"math"
+)
+
+// Buttons which drive a controller for one step
+type ControllerInput struct {
+	// Held directions
+	Left, Right, Up, Down bool
+
+	// Jump was pressed this step, and is being held
+	JumpPressed, JumpHeld bool
+
+	// Dash was pressed this step
+	DashPressed bool
+}
+
+// Moves a body like a platformer character
+// Every timer counts physics steps, so the controller behaves the same on every frame rate
+type PlatformerController struct {
+	// Number of steps after walking off the ground where the body can still jump
+	CoyoteTime int
+
+	// Number of steps a jump press is remembered for before the body can jump
+	JumpBuffer int
+
+	// Upwards speed of a jump
+	JumpSpeed float64
+
+	// Multiplier of the upwards speed when the jump is released early
+	JumpCut float64
+
+	// Number of jumps before landing (2 for double jump, 3 for triple jump ...)
+	Jumps int
+
+	// Slowest vertical speed which counts as the top of a jump
+	ApexSpeed float64
+
+	// Multiplier of the gravity at the top of a jump, while the jump is held
+	ApexGravityScale float64
+
+	// Multiplier of the gravity while falling and holding down
+	FastFallGravityScale float64
+
+	// Fastest speed the body can fall at, and fall at while holding down
+	MaxFallSpeed  float64
+	FastFallSpeed float64
+
+	// Fastest speed the body can slide down a wall at
+	WallSlideSpeed float64
+
+	// Speed of a jump off a wall, away from the wall and upwards
+	WallJumpSpeed Vector2f
+
+	// Number of steps the horizontal input is ignored for after a wall jump
+	WallJumpLockout int
+
+	// How far the top of a ledge can be from the top of the body to grab it
+	LedgeReach float64
+
+	// Number of steps before a ledge or a ladder can be grabbed again after letting go
+	LedgeCooldown int
+
+	// Speed of the body while climbing a ladder
+	ClimbSpeed float64
+
+	// Fraction of the body inside a fluid before it swims (ranges from 0.0 to 1.0)
+	SwimDepth float64
+
+	// Added to the vertical momentum each step while swimming up or down
+	SwimAcceleration float64
+
+	// Fastest speed the body can swim up or down at
+	SwimSpeed float64
+
+	// Steps since the body was on the ground
+	AirTime int
+
+	// Steps left before the remembered jump press is forgotten
+	JumpBuffered int
+
+	// Jumps left before landing
+	JumpsLeft int
+
+	// The body is rising from a jump, which can still be cut short
+	Jumping bool
+
+	// Input which changes the gravity in the physics step
+	HoldingJump, FastFalling bool
+
+	// Side of the wall next to the body in the air, and the side it is sliding down
+	Wall      int
+	WallSlide int
+
+	// Steps left before the horizontal input works again
+	Lockout int
+
+	// Side of the ledge the body is hanging from, and the corner of the ledge
+	Hanging int
+	Ledge   Vector2f
+
+	// Steps left before a ledge or a ladder can be grabbed
+	GrabCooldown int
+
+	// The body is climbing a ladder
+	Climbing bool
+
+	// The body is swimming in a fluid
+	Swimming bool
+}
+
+// Creates a new controller with the default values
+func NewPlatformerController() PlatformerController {
+	controller := PlatformerController{}
+
+	controller.CoyoteTime = 5
+	controller.JumpBuffer = 3
+	controller.JumpSpeed = 5
+	controller.JumpCut = 0.5
+	controller.Jumps = 1
+	controller.ApexSpeed = 1
+	controller.ApexGravityScale = 0.5
+	controller.FastFallGravityScale = 2
+	controller.MaxFallSpeed = 5
+	controller.FastFallSpeed = 8
+	controller.WallSlideSpeed = 1.5
+	controller.WallJumpSpeed = NewVector2f(4, 5)
+	controller.WallJumpLockout = 8
+	controller.LedgeReach = 4
+	controller.LedgeCooldown = 10
+	controller.ClimbSpeed = 1.5
+	controller.SwimDepth = 0.5
+	controller.SwimAcceleration = 0.5
+	controller.SwimSpeed = 2
+
+	controller.JumpsLeft = controller.Jumps
+
+	return controller
+}
+
+// Moves the body from the input
+// The colliders are the walls and ledges near the body, and the ladders are the climbable zones near the body
+// This MUST be handled BEFORE the physics step, which uses the collisions of the previous step
+// The ground, jumps and walls follow the gravity of the body, while the input keeps its directions on the screen
+func (controller *PlatformerController) Update(body *Body, force *Force, settings PhysicsSettings, input ControllerInput, colliders []Collider, ladders []Collider) {
+	turns := gravityTurns(force.Gravity)
+
+	if turns == 0 {
+		controller.update(body, force, settings, input, colliders, ladders)
+		return
+	}
+
+	// Move as if the gravity pulled down, around the position of the body
+	// The body starts at the origin, so rotating it there and back is exact, and it only moves if the controller moved it
+	origin := body.Position
+
+	localBody := NewBody(NewVector2f(0, 0), body.Size).rotateQuarter(turns)
+	localForce := force.rotateQuarter(turns)
+
+	controller.update(&localBody, &localForce, settings, input.rotateQuarter(turns),
+		rotateColliders(colliders, origin, turns), rotateColliders(ladders, origin, turns),
+	)
+
+	if offset := localBody.rotateQuarter(-turns).Position; offset != NewVector2f(0, 0) {
+		body.Position = origin.Add(offset)
+	}
+
+	*force = localForce.rotateQuarter(-turns)
+}
+
+func (controller *PlatformerController) update(body *Body, force *Force, settings PhysicsSettings, input ControllerInput, colliders []Collider, ladders []Collider) {
+	// Down and jump on a one-way platform drops through it instead of jumping
+	if input.JumpPressed && input.Down && force.Collisions.Platform {
+		// Ignore the platforms for long enough to fall below their edge
+		force.DropThrough = 10
+
+		// The body is no longer on the ground, and cannot coyote jump back up
+		force.Collisions.Down = false
+		force.Collisions.Platform = false
+
+		controller.AirTime = controller.CoyoteTime + 1
+		controller.JumpsLeft = controller.Jumps - 1
+
+		input.JumpPressed = false
+	}
+
+	controller.GrabCooldown = max(0, controller.GrabCooldown-1)
+
+	// Hanging bodies only climb up, jump or let go
+	if controller.Hanging != SideNone {
+		controller.hang(body, force, input)
+
+		controller.HoldingJump = input.JumpHeld
+		controller.FastFalling = false
+
+		return
+	}
+
+	// Climbing bodies only move along the ladder
+	if controller.climb(body, force, input, ladders) {
+		controller.HoldingJump = input.JumpHeld
+		controller.FastFalling = false
+
+		return
+	}
+
+	// Swimming bodies move up and down through the fluid
+	controller.Swimming = force.Submerged >= controller.SwimDepth
+
+	if controller.Swimming {
+		controller.swim(force, settings, input)
+
+		// The jump button swims up instead of slowing the fall
+		controller.HoldingJump = false
+		controller.FastFalling = false
+
+		return
+	}
+
+	// Ignore the horizontal input after a wall jump, so the body moves away from the wall
+	if controller.Lockout > 0 {
+		controller.Lockout -= 1
+
+		input.Left = false
+		input.Right = false
+	}
+
+	controller.move(force, settings, input)
+
+	if controller.wall(body, force, input, colliders) {
+		controller.HoldingJump = input.JumpHeld
+		controller.FastFalling = false
+
+		return
+	}
+
+	controller.jump(force, input)
+
+	// Remember the input for the gravity of the physics step
+	controller.HoldingJump = input.JumpHeld
+	controller.FastFalling = input.Down && !force.Collisions.Down && force.Acceleration.Y > 0 && controller.WallSlide == SideNone
+}
+
+// Slides down walls and grabs ledges
+// Returns true if the body grabbed a ledge
+func (controller *PlatformerController) wall(body *Body, force *Force, input ControllerInput, colliders []Collider) bool {
+	controller.Wall = SideNone
+	controller.WallSlide = SideNone
+
+	// Walls only matter in the air
+	if force.Collisions.Down {
+		return false
+	}
+
+	side := body.WallSide(colliders, input)
+	controller.Wall = side
+
+	// The body has to hold into the wall
+	holding := (side == SideLeft && input.Left) || (side == SideRight && input.Right)
+
+	if !holding || force.Acceleration.Y < 0 {
+		return false
+	}
+
+	// Grab the ledge at the top of the wall
+	if controller.GrabCooldown == 0 {
+		if ledge, ok := body.FindLedge(colliders, side, controller.LedgeReach); ok {
+			controller.Hanging = side
+			controller.Ledge = ledge
+
+			// Hang with the top of the body level with the ledge
+			body.Position.Y = ledge.Y
+			force.Acceleration = NewVector2f(0, 0)
+
+			return true
+		}
+	}
+
+	// Slide down the wall
+	controller.WallSlide = side
+
+	return false
+}
+
+// Hangs from a ledge until the body climbs up, jumps or lets go
+func (controller *PlatformerController) hang(body *Body, force *Force, input ControllerInput) {
+	// Stay still on the ledge
+	force.Acceleration = NewVector2f(0, 0)
+
+	switch {
+	case input.JumpPressed:
+		// Jump up from the ledge
+		force.Acceleration.Y = -controller.JumpSpeed
+		controller.Jumping = true
+	case input.Up:
+		// Climb onto the ledge
+		body.Position.Y = controller.Ledge.Y - body.Size.Y
+
+		if controller.Hanging == SideRight {
+			body.Position.X = controller.Ledge.X
+		} else {
+			body.Position.X = controller.Ledge.X - body.Size.X
+		}
+	case input.Down:
+		// Let go of the ledge
+	default:
+		return
+	}
+
+	controller.Hanging = SideNone
+	controller.GrabCooldown = controller.LedgeCooldown
+}
+
+// Swims up and down through a fluid, and leaps out at the surface
+func (controller *PlatformerController) swim(force *Force, settings PhysicsSettings, input ControllerInput) {
+	// Swimming counts as standing on the ground, so the body can jump out of the fluid
+	controller.AirTime = 0
+	controller.JumpsLeft = controller.Jumps
+	controller.Jumping = false
+	controller.Wall = SideNone
+	controller.WallSlide = SideNone
+
+	controller.move(force, settings, input)
+
+	// Leap out of the fluid when the body is at the surface
+	if input.JumpPressed && force.Submerged < 1 {
+		force.Acceleration.Y = -controller.JumpSpeed
+
+		controller.JumpsLeft -= 1
+		controller.Jumping = true
+		controller.AirTime = controller.CoyoteTime + 1
+
+		return
+	}
+
+	// Swim up
+	if input.Up || input.JumpHeld {
+		force.Acceleration.Y -= controller.SwimAcceleration
+		force.Acceleration.Y = max(-controller.SwimSpeed, force.Acceleration.Y)
+	}
+
+	// Swim down
+	if input.Down {
+		force.Acceleration.Y += controller.SwimAcceleration
+		force.Acceleration.Y = min(controller.SwimSpeed, force.Acceleration.Y)
+	}
+}
+
+func (controller *PlatformerController) move(force *Force, settings PhysicsSettings, input ControllerInput) {
+	if input.Left {
+		// Add the momemtum
+		force.Acceleration.X -= settings.MoveAcceleration
+
+		// Limit the momentum
+		force.Acceleration.X = max(-settings.MoveSpeed, force.Acceleration.X)
+	}
+
+	if input.Right {
+		// Add the momentum
+		force.Acceleration.X += settings.MoveAcceleration
+
+		// Limit the momentum
+		force.Acceleration.X = min(settings.MoveSpeed, force.Acceleration.X)
+	}
+
+	// If there is horizontal collision, half the acceleration
+	if force.Collisions.Left || force.Collisions.Right {
+		force.Acceleration.X /= 2
+	}
+}
+
+func (controller *PlatformerController) jump(force *Force, input ControllerInput) {
+	if force.Collisions.Down {
+		// Landing gives back all the jumps
+		controller.AirTime = 0
+		controller.JumpsLeft = controller.Jumps
+		controller.Jumping = false
+	} else {
+		controller.AirTime += 1
+
+		// Walking off the ground uses up the jump from the ground once coyote time is over
+		if controller.AirTime > controller.CoyoteTime && controller.JumpsLeft == controller.Jumps {
+			controller.JumpsLeft -= 1
+		}
+	}
+
+	if force.Collisions.Up {
+		// Reset the velocity when the body bonks on its top
+		force.Acceleration.Y = max(0, force.Acceleration.Y)
+	}
+
+	// Register a jump
+	// A jump can be registered even if the body has not yet touched the ground
+	if input.JumpPressed {
+		controller.JumpBuffered = controller.JumpBuffer
+	}
+
+	// If the body does not hit the ground in time, it won't jump
+	// Only the walls the body is sliding down or holding into can be jumped off
+	holdingWall := (controller.Wall == SideLeft && input.Left) || (controller.Wall == SideRight && input.Right)
+
+	if controller.JumpBuffered > 0 {
+		if controller.WallSlide != SideNone || holdingWall {
+			// Jump away from the wall
+			force.Acceleration.X = -float64(controller.Wall) * controller.WallJumpSpeed.X
+			force.Acceleration.Y = -controller.WallJumpSpeed.Y
+
+			// Stop registering the jump
+			controller.JumpBuffered = 0
+
+			controller.Jumping = true
+			controller.WallSlide = SideNone
+
+			// Keep moving away from the wall for a while
+			controller.Lockout = controller.WallJumpLockout
+		} else if controller.JumpsLeft > 0 {
+			// How high it goes (and the actual jump part)
+			force.Acceleration.Y = -controller.JumpSpeed
+
+			// Take off an available jump
+			controller.JumpsLeft -= 1
+
+			// Stop registering the jump
+			controller.JumpBuffered = 0
+
+			controller.Jumping = true
+
+			// Coyote time is over once the body has jumped
+			controller.AirTime = controller.CoyoteTime + 1
+		} else {
+			// Tick down the timer of the register
+			controller.JumpBuffered -= 1
+		}
+	}
+
+	// Releasing the jump early makes it lower
+	if controller.Jumping {
+		if force.Acceleration.Y >= 0 {
+			controller.Jumping = false
+		} else if !input.JumpHeld {
+			force.Acceleration.Y *= controller.JumpCut
+			controller.Jumping = false
+		}
+	}
+}
+
+// Applies the gravity of the controlled body
+// The gravity is lower at the top of a jump, and higher when falling fast
+// Bodies sliding down a wall fall slower
+func (controller *PlatformerController) UpdateGravity(force *Force, material PhysicsMaterial) {
+	// Hanging and climbing bodies don't fall
+	if controller.Hanging != SideNone || controller.Climbing {
+		return
+	}
+
+	force.withGravityDown(func(local *Force) {
+		controller.updateGravity(local, material)
+	})
+}
+
+func (controller *PlatformerController) updateGravity(force *Force, material PhysicsMaterial) {
+	gravity := force.Gravity.Magnitude() * material.GravityScale
+	maxFallSpeed := controller.MaxFallSpeed
+
+	if controller.WallSlide != SideNone {
+		maxFallSpeed = controller.WallSlideSpeed
+	} else if controller.FastFalling {
+		gravity *= controller.FastFallGravityScale
+		maxFallSpeed = controller.FastFallSpeed
+	} else if controller.HoldingJump && !force.Collisions.Down && math.Abs(force.Acceleration.Y) < controller.ApexSpeed {
+		gravity *= controller.ApexGravityScale
+	}
+
+	force.ApplyGravity(NewVector2f(0, gravity), maxFallSpeed)
+}
@@ -0,0 +1,447 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// Scripted controller which is stepped frame by frame without any colliders
+// The collisions of the previous step are set by the test, instead of by the tiles
+type controllerScript struct {
+	controller PlatformerController
+	body       Body
+	force      Force
+	settings   PhysicsSettings
+	material   PhysicsMaterial
+
+	// Walls and ledges near the body
+	colliders []Collider
+}
+
+func newControllerScript() *controllerScript {
+	script := &controllerScript{}
+
+	script.controller = NewPlatformerController()
+	script.body = NewBody(NewVector2f(0, 0), NewVector2f(16, 16))
+	script.force = NewForce(NewVector2f(0, 0), NewVector2f(0, 0))
+	script.settings = NewPhysicsSettings()
+	script.material = NewPhysicsMaterial()
+
+	script.force.Gravity = script.settings.Gravity
+
+	return script
+}
+
+// Runs the controller and its gravity for one step
+func (script *controllerScript) step(input ControllerInput, grounded bool) {
+	script.force.Collisions = Collisions{Down: grounded}
+
+	// Standing bodies don't fall through the ground
+	if grounded {
+		script.force.Acceleration.Y = min(0, script.force.Acceleration.Y)
+	}
+
+	script.controller.Update(&script.body, &script.force, script.settings, input, script.colliders, nil)
+	script.controller.UpdateGravity(&script.force, script.material)
+}
+
+// Lands on the ground, and walks off it without jumping
+func (script *controllerScript) walkOffLedge() {
+	script.step(ControllerInput{}, true)
+}
+
+func approximately(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCoyoteTime(t *testing.T) {
+	jump := ControllerInput{JumpPressed: true, JumpHeld: true}
+
+	// The last step of coyote time can still jump
+	script := newControllerScript()
+	script.walkOffLedge()
+
+	for range script.controller.CoyoteTime - 1 {
+		script.step(ControllerInput{}, false)
+	}
+
+	script.step(jump, false)
+
+	if !script.controller.Jumping || script.force.Acceleration.Y >= 0 {
+		t.Errorf("jump at the end of coyote time failed, acceleration %v", script.force.Acceleration)
+	}
+
+	// One step later the jump from the ground is gone
+	script = newControllerScript()
+	script.walkOffLedge()
+
+	for range script.controller.CoyoteTime {
+		script.step(ControllerInput{}, false)
+	}
+
+	script.step(jump, false)
+
+	if script.controller.Jumping || script.force.Acceleration.Y < 0 {
+		t.Errorf("jump after coyote time expired, acceleration %v", script.force.Acceleration)
+	}
+
+	if script.controller.JumpsLeft != 0 {
+		t.Errorf("jumps left after coyote time = %d, want 0", script.controller.JumpsLeft)
+	}
+}
+
+func TestJumpBuffer(t *testing.T) {
+	jump := ControllerInput{JumpPressed: true, JumpHeld: true}
+	held := ControllerInput{JumpHeld: true}
+
+	// A press just before landing jumps on the landing step
+	script := newControllerScript()
+	script.walkOffLedge()
+
+	for range script.controller.CoyoteTime + 1 {
+		script.step(ControllerInput{}, false)
+	}
+
+	script.step(jump, false)
+
+	if script.controller.Jumping {
+		t.Fatalf("jumped in the air without any jumps left")
+	}
+
+	script.step(held, true)
+
+	if !script.controller.Jumping || script.force.Acceleration.Y >= 0 {
+		t.Errorf("buffered jump did not jump on landing, acceleration %v", script.force.Acceleration)
+	}
+
+	// The buffered press is used up by the jump
+	if script.controller.JumpBuffered != 0 {
+		t.Errorf("jump buffer after jumping = %d, want 0", script.controller.JumpBuffered)
+	}
+
+	// Landing again does not jump a second time from the same press
+	script.force.Acceleration = NewVector2f(0, 0)
+	script.controller.Jumping = false
+	script.step(held, true)
+
+	if script.controller.Jumping || script.force.Acceleration.Y < 0 {
+		t.Errorf("buffered jump was used twice, acceleration %v", script.force.Acceleration)
+	}
+
+	// A press which is too early is forgotten before landing
+	script = newControllerScript()
+	script.walkOffLedge()
+
+	for range script.controller.CoyoteTime + 1 {
+		script.step(ControllerInput{}, false)
+	}
+
+	script.step(jump, false)
+
+	for range script.controller.JumpBuffer - 1 {
+		script.step(held, false)
+	}
+
+	script.step(held, true)
+
+	if script.controller.Jumping || script.force.Acceleration.Y < 0 {
+		t.Errorf("expired jump buffer jumped on landing, acceleration %v", script.force.Acceleration)
+	}
+}
+
+// Returns the height of a jump from the ground, where the jump is held for a number of steps
+func jumpHeight(holdSteps int) float64 {
+	script := newControllerScript()
+
+	script.step(ControllerInput{JumpPressed: true, JumpHeld: true}, true)
+
+	height := 0.0
+	rise := -script.force.Acceleration.Y
+
+	for step := 1; rise > 0; step++ {
+		height += rise
+
+		script.step(ControllerInput{JumpHeld: step < holdSteps}, false)
+		rise = -script.force.Acceleration.Y
+	}
+
+	return height
+}
+
+func TestVariableJumpHeight(t *testing.T) {
+	full := jumpHeight(math.MaxInt)
+	short := jumpHeight(2)
+
+	if short >= full {
+		t.Errorf("released jump height %v, want lower than the held jump height %v", short, full)
+	}
+
+	// Releasing the jump cuts the upwards speed
+	script := newControllerScript()
+	script.step(ControllerInput{JumpPressed: true, JumpHeld: true}, true)
+
+	before := script.force.Acceleration.Y
+	script.step(ControllerInput{}, false)
+
+	want := before*script.controller.JumpCut + script.settings.Gravity.Y
+
+	if !approximately(script.force.Acceleration.Y, want) {
+		t.Errorf("acceleration after release = %v, want %v", script.force.Acceleration.Y, want)
+	}
+
+	if script.controller.Jumping {
+		t.Errorf("still jumping after the jump was released")
+	}
+}
+
+func TestJumpCount(t *testing.T) {
+	for _, jumps := range []int{1, 2, 3} {
+		script := newControllerScript()
+		script.controller.Jumps = jumps
+		script.controller.JumpsLeft = jumps
+
+		count := 0
+
+		for press := range jumps + 1 {
+			script.step(ControllerInput{}, press == 0)
+
+			script.force.Acceleration.Y = 0
+			script.step(ControllerInput{JumpPressed: true, JumpHeld: true}, press == 0)
+
+			if script.force.Acceleration.Y < 0 {
+				count++
+			}
+
+			// Forget the press, so it does not jump on a later step
+			script.controller.JumpBuffered = 0
+		}
+
+		if count != jumps {
+			t.Errorf("%d jumps: jumped %d times", jumps, count)
+		}
+	}
+}
+
+func TestApexGravity(t *testing.T) {
+	gravity := NewPhysicsSettings().Gravity.Y
+
+	for _, test := range []struct {
+		name         string
+		acceleration float64
+		input        ControllerInput
+		want         float64
+	}{
+		{"apex held", 0.5, ControllerInput{JumpHeld: true}, gravity * NewPlatformerController().ApexGravityScale},
+		{"apex released", 0.5, ControllerInput{}, gravity},
+		{"fast rising held", -3, ControllerInput{JumpHeld: true}, gravity},
+		{"fast falling held", 3, ControllerInput{JumpHeld: true}, gravity},
+	} {
+		script := newControllerScript()
+		script.force.Acceleration.Y = test.acceleration
+
+		script.step(test.input, false)
+
+		if got := script.force.Acceleration.Y - test.acceleration; !approximately(got, test.want) {
+			t.Errorf("%s: gravity = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFastFall(t *testing.T) {
+	script := newControllerScript()
+	script.force.Acceleration.Y = 1
+
+	script.step(ControllerInput{Down: true}, false)
+
+	want := script.settings.Gravity.Y * script.controller.FastFallGravityScale
+
+	if got := script.force.Acceleration.Y - 1; !approximately(got, want) {
+		t.Errorf("fast fall gravity = %v, want %v", got, want)
+	}
+
+	// Rising bodies don't fast fall
+	script = newControllerScript()
+	script.force.Acceleration.Y = -1
+
+	script.step(ControllerInput{Down: true}, false)
+
+	if got := script.force.Acceleration.Y + 1; !approximately(got, script.settings.Gravity.Y) {
+		t.Errorf("rising gravity while holding down = %v, want %v", got, script.settings.Gravity.Y)
+	}
+}
+
+func TestMaxFallSpeed(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		input ControllerInput
+		want  float64
+	}{
+		{"falling", ControllerInput{}, NewPlatformerController().MaxFallSpeed},
+		{"fast falling", ControllerInput{Down: true}, NewPlatformerController().FastFallSpeed},
+	} {
+		script := newControllerScript()
+
+		for range 100 {
+			script.step(test.input, false)
+		}
+
+		if !approximately(script.force.Acceleration.Y, test.want) {
+			t.Errorf("%s: fall speed = %v, want %v", test.name, script.force.Acceleration.Y, test.want)
+		}
+	}
+}
+
+func TestWallJump(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		input    ControllerInput
+		wallJump bool
+	}{
+		{"holding into the wall", ControllerInput{Right: true, JumpPressed: true, JumpHeld: true}, true},
+		{"holding away from the wall", ControllerInput{Left: true, JumpPressed: true, JumpHeld: true}, false},
+		{"not holding", ControllerInput{JumpPressed: true, JumpHeld: true}, false},
+	} {
+		script := newControllerScript()
+
+		// Falling next to a wall on the right, without any jumps left
+		script.colliders = []Collider{NewCollider(NewBody(NewVector2f(16, -32), NewVector2f(16, 64)))}
+		script.controller.JumpsLeft = 0
+		script.controller.AirTime = script.controller.CoyoteTime + 1
+		script.force.Acceleration.Y = 1
+
+		script.step(test.input, false)
+
+		wallJumped := script.force.Acceleration.X < 0 && script.force.Acceleration.Y < 0
+
+		if wallJumped != test.wallJump {
+			t.Errorf("%s: wall jumped = %v, want %v, acceleration %v", test.name, wallJumped, test.wallJump, script.force.Acceleration)
+		}
+	}
+}
+
+func TestSidewaysGravityKeepsPosition(t *testing.T) {
+	for _, gravity := range []Vector2f{NewVector2f(0.3, 0), NewVector2f(-0.3, 0), NewVector2f(0, -0.3)} {
+		script := newControllerScript()
+		script.body = NewBody(NewVector2f(100.1, 37.3), NewVector2f(16, 24))
+		script.force.Gravity = gravity
+
+		want := script.body
+
+		for range 100 {
+			script.step(ControllerInput{}, false)
+		}
+
+		// Rotating into the gravity and back must not move the body
+		if script.body != want {
+			t.Errorf("gravity %v: body = %v, want %v", gravity, script.body, want)
+		}
+	}
+}
+
+// Runs the controller and its gravity, and resolves the collisions with the colliders of the script
+// Bodies with a shape are resolved as the shape, and the others as rectangles
+func (script *controllerScript) move(input ControllerInput, shape *BodyShape) {
+	script.controller.Update(&script.body, &script.force, script.settings, input, script.colliders, nil)
+	script.controller.UpdateGravity(&script.force, script.material)
+
+	// Standing bodies don't fall through the ground
+	if script.force.Collisions.Down {
+		script.force.Acceleration.Y = min(0, script.force.Acceleration.Y)
+	}
+
+	script.force.Velocity = script.force.Acceleration
+
+	if shape != nil {
+		script.body.CollidesWithShape(shape.Fit(script.body), script.colliders, &script.force)
+	} else {
+		script.body.CollidiesWithDynamicBodies(script.colliders, &script.force)
+	}
+
+	script.body.Position = script.body.Position.Add(script.force.Velocity)
+	script.force.Velocity = NewVector2f(0, 0)
+}
+
+func TestWalkUpSlope(t *testing.T) {
+	roundedBox := NewBodyShape(ShapeRoundedBox)
+
+	for _, test := range []struct {
+		name  string
+		shape *BodyShape
+	}{
+		{"box", nil},
+		{"rounded box", &roundedBox},
+	} {
+		script := newControllerScript()
+		script.body = NewBody(NewVector2f(-32, 48), NewVector2f(16, 16))
+
+		// Flat ground on the left, and a 45° slope rising to the right
+		script.colliders = []Collider{NewCollider(NewBody(NewVector2f(-64, 64), NewVector2f(64, 16)))}
+
+		for i := range 10 {
+			slope := NewCollider(NewBody(NewVector2f(float64(i)*16, 48-float64(i)*16), NewVector2f(16, 16)))
+			slope.Slope = SlopeFloorRight45
+
+			script.colliders = append(script.colliders, slope)
+		}
+
+		for range 40 {
+			script.move(ControllerInput{Right: true}, test.shape)
+		}
+
+		// The body walks up the slope as fast as on flat ground
+		before := script.body.Position
+		script.move(ControllerInput{Right: true}, test.shape)
+		moved := script.body.Position.Sub(before)
+
+		if !approximately(moved.X, script.settings.MoveSpeed) {
+			t.Errorf("%s: walked %v along the slope, want %v", test.name, moved.X, script.settings.MoveSpeed)
+		}
+
+		if moved.Y >= 0 || !script.force.Collisions.Slope {
+			t.Errorf("%s: moved %v, slope %v, want to climb the slope", test.name, moved, script.force.Collisions.Slope)
+		}
+	}
+}
+
+func TestLedgeClimb(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		input ControllerInput
+		climb bool
+		jump  bool
+	}{
+		{"tap up", ControllerInput{Up: true}, true, false},
+		{"jump", ControllerInput{JumpPressed: true, JumpHeld: true}, false, true},
+		{"let go", ControllerInput{Down: true}, false, false},
+	} {
+		script := newControllerScript()
+
+		// Falling beside a wall on the right, whose top is within reach
+		script.colliders = []Collider{NewCollider(NewBody(NewVector2f(16, 2), NewVector2f(16, 64)))}
+		script.controller.JumpsLeft = 0
+		script.controller.AirTime = script.controller.CoyoteTime + 1
+		script.force.Acceleration.Y = 1
+
+		script.step(ControllerInput{Right: true}, false)
+
+		if script.controller.Hanging != SideRight {
+			t.Fatalf("%s: did not grab the ledge", test.name)
+		}
+
+		script.step(test.input, false)
+
+		if script.controller.Hanging != SideNone {
+			t.Errorf("%s: still hanging from the ledge", test.name)
+		}
+
+		// Climbing stands the body on top of the ledge
+		climbed := script.body.Position == NewVector2f(16, 2-script.body.Size.Y)
+		if climbed != test.climb {
+			t.Errorf("%s: climbed = %v, want %v, body %v", test.name, climbed, test.climb, script.body)
+		}
+
+		if jumped := script.controller.Jumping; jumped != test.jump {
+			t.Errorf("%s: jumped = %v, want %v", test.name, jumped, test.jump)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package physics
+
+const (
+	// Dash states
+	DashReady int = iota
+	DashActive
+	DashCooldown
+)
+
+const (
+	// How the dash speed changes over the dash
+	DashCurveLinear int = iota
+	DashCurveEaseOut
+	DashCurveEaseInOut
+)
+
+// Quick burst of movement in any of the 8 directions
+// Every timer counts physics steps
+type Dash struct {
+	// Number of steps the dash lasts for
+	Duration int
+
+	// How far the dash moves the body
+	Distance float64
+
+	// How the dash speed changes over the dash
+	Curve int
+
+	// Number of steps after a dash before the next dash
+	Cooldown int
+
+	// Number of dashes before landing
+	Charges int
+
+	// Number of steps from the start of the dash where the body can't be hurt
+	IFrameTime int
+
+	// Dash state
+	State int
+
+	// Steps into the dash, or steps left of the cooldown
+	Timer int
+
+	// Dashes left before landing
+	ChargesLeft int
+
+	// Direction of the current dash, with a length of 1
+	Direction Vector2f
+
+	// Side the body last moved towards, used when no direction is held
+	Facing int
+
+	// Steps left where the body can't be hurt
+	IFrames int
+}
+
+// Creates a new dash with the default values
+func NewDash() Dash {
+	dash := Dash{}
+
+	dash.Duration = 8
+	dash.Distance = 64
+	dash.Curve = DashCurveEaseOut
+	dash.Cooldown = 20
+	dash.Charges = 1
+	dash.IFrameTime = 6
+
+	dash.State = DashReady
+	dash.Timer = 0
+	dash.ChargesLeft = dash.Charges
+	dash.Facing = SideRight
+
+	return dash
+}
+
+// Returns the fraction of the distance travelled at a fraction of the duration (both range from 0.0 to 1.0)
+func (dash Dash) curve(t float64) float64 {
+	switch dash.Curve {
+	case DashCurveEaseOut:
+		return 1 - float64((1-t)*(1-t))
+	case DashCurveEaseInOut:
+		return float64(t*t) * (3 - float64(2*t))
+	}
+
+	return t
+}
+
+// Returns true while the body is dashing
+// Gravity does not affect dashing bodies
+func (dash Dash) Active() bool {
+	return dash.State == DashActive
+}
+
+// Returns true if the body can't be hurt
+func (dash Dash) Invincible() bool {
+	return dash.IFrames > 0
+}
+
+// Stops the dash early, like when the body grabs a ledge or a ladder
+// The cooldown starts, and the body can be hurt again
+func (dash *Dash) Cancel() {
+	if dash.State != DashActive {
+		return
+	}
+
+	dash.State = DashCooldown
+	dash.Timer = dash.Cooldown
+	dash.IFrames = 0
+}
+
+// Starts and moves the dash from the input
+// This MUST be handled AFTER the controller, so the dash overrides the jump and movement
+func (dash *Dash) Update(force *Force, input ControllerInput) {
+	// Remember the direction the body faces
+	if input.Left && !input.Right {
+		dash.Facing = SideLeft
+	} else if input.Right && !input.Left {
+		dash.Facing = SideRight
+	}
+
+	dash.IFrames = max(0, dash.IFrames-1)
+
+	// Landing gives back all the dashes
+	if force.Collisions.Grounded(force.Gravity) && dash.State != DashActive {
+		dash.ChargesLeft = dash.Charges
+	}
+
+	// Start a new dash
+	if input.DashPressed && dash.State == DashReady && dash.ChargesLeft > 0 {
+		dash.ChargesLeft -= 1
+
+		dash.State = DashActive
+		dash.Timer = 0
+		dash.IFrames = dash.IFrameTime
+
+		// Dash in the held direction, or forwards if nothing is held
+		var direction Vector2f
+
+		if input.Left {
+			direction.X -= 1
+		}
+		if input.Right {
+			direction.X += 1
+		}
+		if input.Up {
+			direction.Y -= 1
+		}
+		if input.Down {
+			direction.Y += 1
+		}
+
+		if direction.X == 0 && direction.Y == 0 {
+			direction.X = float64(dash.Facing)
+		}
+
+		// Diagonal dashes go as far as straight ones
+		dash.Direction = direction.Normalize()
+	}
+
+	switch dash.State {
+	case DashActive:
+		dash.Timer += 1
+
+		// Move by the part of the distance covered this step
+		start := dash.curve(float64(dash.Timer-1) / float64(dash.Duration))
+		end := dash.curve(float64(dash.Timer) / float64(dash.Duration))
+		distance := dash.Distance * (end - start)
+
+		force.Velocity = force.Velocity.Add(dash.Direction.Scale(distance))
+
+		// The dash replaces the momentum of the body
+		force.Acceleration = NewVector2f(0, 0)
+
+		if dash.Timer >= dash.Duration {
+			dash.State = DashCooldown
+			dash.Timer = dash.Cooldown
+		}
+	case DashCooldown:
+		dash.Timer -= 1
+
+		if dash.Timer <= 0 {
+			dash.State = DashReady
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package physics
+
+// Contact point between a moving body and a collider
+type DebugContact struct {
+	Point  Vector2f
+	Normal Vector2f
+}
+
+// Ray cast during the step, and where it hit
+type DebugRay struct {
+	Start, End Vector2f
+
+	Hit   bool
+	Point Vector2f
+}
+
+// Shapes recorded during the physics step, so they can be drawn on top of the game
+// Nothing is recorded unless the debug data is enabled
+// The physics systems record the movement of the bodies, and the physics world records its queries
+type DebugData struct {
+	Enabled bool
+
+	// Areas checked by the broad phase of the moving bodies
+	BroadPhases []Body
+
+	// Where the moving bodies hit the colliders
+	Contacts []DebugContact
+
+	// Movement of each body during the step, from the center of the body
+	Velocities []DebugRay
+
+	// Rays cast against the world
+	Raycasts []DebugRay
+
+	// Areas checked for overlapping colliders
+	Triggers []Body
+	Circles  []Circle
+}
+
+// Creates new debug data, which is disabled
+func NewDebugData() DebugData {
+	debug := DebugData{}
+
+	debug.Enabled = false
+	debug.BroadPhases = make([]Body, 0)
+	debug.Contacts = make([]DebugContact, 0)
+	debug.Velocities = make([]DebugRay, 0)
+	debug.Raycasts = make([]DebugRay, 0)
+	debug.Triggers = make([]Body, 0)
+	debug.Circles = make([]Circle, 0)
+
+	return debug
+}
+
+// Returns true if the shapes are recorded
+// Nothing is recorded into missing debug data
+func (debug *DebugData) Recording() bool {
+	return debug != nil && debug.Enabled
+}
+
+// Removes the shapes of the previous step
+func (debug *DebugData) Clear() {
+	debug.BroadPhases = debug.BroadPhases[:0]
+	debug.Contacts = debug.Contacts[:0]
+	debug.Velocities = debug.Velocities[:0]
+	debug.Raycasts = debug.Raycasts[:0]
+	debug.Triggers = debug.Triggers[:0]
+	debug.Circles = debug.Circles[:0]
+}
+
+func (debug *DebugData) AddBroadPhase(area Body) {
+	if debug.Recording() {
+		debug.BroadPhases = append(debug.BroadPhases, area)
+	}
+}
+
+// Records where the contact touched the collider, and the normal of the surface
+func (debug *DebugData) AddContact(contact Contact) {
+	if debug.Recording() {
+		debug.Contacts = append(debug.Contacts, DebugContact{contact.Point, contact.Normal})
+	}
+}
+
+func (debug *DebugData) AddVelocity(body Body, velocity Vector2f) {
+	if !debug.Recording() {
+		return
+	}
+
+	center := body.Center()
+
+	debug.Velocities = append(debug.Velocities, DebugRay{Start: center, End: center.Add(velocity)})
+}
+
+func (debug *DebugData) AddRaycast(start, direction Vector2f, hits []RaycastHit) {
+	if !debug.Recording() {
+		return
+	}
+
+	ray := DebugRay{Start: start, End: start.Add(direction)}
+
+	// Only the first hit is drawn
+	if len(hits) > 0 {
+		ray.Hit = true
+		ray.Point = hits[0].Point
+	}
+
+	debug.Raycasts = append(debug.Raycasts, ray)
+}
+
+func (debug *DebugData) AddTrigger(area Body) {
+	if debug.Recording() {
+		debug.Triggers = append(debug.Triggers, area)
+	}
+}
+
+func (debug *DebugData) AddCircle(circle Circle) {
+	if debug.Recording() {
+		debug.Circles = append(debug.Circles, circle)
+	}
+}
@@ -0,0 +1,92 @@
+package physics
+
+const (
+	// How a force field pushes the bodies in it
+	FieldDirectional int = iota
+	FieldRadial
+)
+
+// Area which pushes the bodies in it every step, like wind, updrafts and repulsors
+type ForceField struct {
+	Kind int
+
+	// Push of a directional field
+	Push Vector2f
+
+	// Push of a radial field away from its center, or towards it if the strength is negative
+	Strength float64
+
+	// How a radial field weakens towards the edge of its area
+	Falloff int
+
+	// Layers of the bodies which are pushed
+	Mask uint32
+}
+
+// Creates a new field which pushes every layer in the same direction
+func NewForceField(push Vector2f) ForceField {
+	field := ForceField{}
+
+	field.Kind = FieldDirectional
+	field.Push = push
+	field.Strength = 0
+	field.Falloff = FalloffNone
+	field.Mask = LayerAll
+
+	return field
+}
+
+// Creates a new field which pushes every layer away from its center, and weakens linearly
+func NewRadialForceField(strength float64) ForceField {
+	field := ForceField{}
+
+	field.Kind = FieldRadial
+	field.Push = NewVector2f(0, 0)
+	field.Strength = strength
+	field.Falloff = FalloffLinear
+	field.Mask = LayerAll
+
+	return field
+}
+
+// Returns the push of the field covering the area on the body
+// Bodies partly inside the area are pushed less
+func (field ForceField) PushAt(area, body Body) Vector2f {
+	overlap, ok := body.Intersection(area)
+
+	if !ok || body.Area() == 0 {
+		return NewVector2f(0, 0)
+	}
+
+	inside := overlap.Area() / body.Area()
+
+	if field.Kind == FieldDirectional {
+		return field.Push.Scale(inside)
+	}
+
+	// Radial fields reach the closest edge of the area
+	radius := min(area.Size.X, area.Size.Y) / 2
+	direction := body.Center().Sub(area.Center())
+	distance := direction.Magnitude()
+
+	if radius == 0 || distance == 0 || distance > radius {
+		return NewVector2f(0, 0)
+	}
+
+	strength := field.Strength * falloff(field.Falloff, distance, radius) * inside
+
+	return direction.Normalize().Scale(strength)
+}
+
+// Moves the body with the surface it stands on, without changing its momentum
+// The surface carries the body along the ground, which is the side the gravity pulls the body to
+func (force *Force) Carry() {
+	if !force.Collisions.Grounded(force.Gravity) {
+		return
+	}
+
+	// Along the ground, the surface turns clockwise towards the right of the gravity
+	along := NewVector2f(force.Collisions.Material.SurfaceSpeed, 0).rotateQuarter(-gravityTurns(force.Gravity))
+
+	force.Velocity = force.Velocity.Add(along)
+}
@@ -0,0 +1,66 @@
+package physics
+
+import "testing"
+
+func TestCarry(t *testing.T) {
+	conveyor := NewPhysicsMaterial()
+	conveyor.SurfaceSpeed = 2
+
+	// The belt turns clockwise around the tile, whichever side the body stands on
+	for _, test := range []struct {
+		name    string
+		gravity Vector2f
+		ground  Collisions
+		want    Vector2f
+	}{
+		{"down", NewVector2f(0, 0.3), Collisions{Down: true}, NewVector2f(2, 0)},
+		{"right", NewVector2f(0.3, 0), Collisions{Right: true}, NewVector2f(0, -2)},
+		{"up", NewVector2f(0, -0.3), Collisions{Up: true}, NewVector2f(-2, 0)},
+		{"left", NewVector2f(-0.3, 0), Collisions{Left: true}, NewVector2f(0, 2)},
+		{"in the air", NewVector2f(0.3, 0), Collisions{Down: true}, NewVector2f(0, 0)},
+	} {
+		force := NewForce(NewVector2f(0, 0), NewVector2f(0, 0))
+		force.Gravity = test.gravity
+		force.Collisions = test.ground
+		force.Collisions.Material = conveyor
+
+		force.Carry()
+
+		if force.Velocity != test.want {
+			t.Errorf("%s: carried %v, want %v", test.name, force.Velocity, test.want)
+		}
+	}
+}
+
+func TestGroundMaterial(t *testing.T) {
+	ground := NewPhysicsMaterial()
+	ground.Friction = 1
+
+	wall := NewPhysicsMaterial()
+	wall.Friction = 0
+
+	// The ground is hit first, and then the wall beside it
+	for _, test := range []struct {
+		name    string
+		gravity Vector2f
+		ground  Vector2f
+		wall    Vector2f
+	}{
+		{"down", NewVector2f(0, 0.3), NewVector2f(0, -1), NewVector2f(-1, 0)},
+		{"right", NewVector2f(0.3, 0), NewVector2f(-1, 0), NewVector2f(0, 1)},
+		{"up", NewVector2f(0, -0.3), NewVector2f(0, 1), NewVector2f(1, 0)},
+		{"left", NewVector2f(-0.3, 0), NewVector2f(1, 0), NewVector2f(0, -1)},
+	} {
+		collisions := Collisions{}
+
+		collisions.UpdateMaterial(ground, test.ground, test.gravity)
+		collisions.Update(test.ground)
+
+		collisions.UpdateMaterial(wall, test.wall, test.gravity)
+		collisions.Update(test.wall)
+
+		if collisions.Material.Friction != ground.Friction {
+			t.Errorf("%s: friction %v, want the friction of the ground %v", test.name, collisions.Material.Friction, ground.Friction)
+		}
+	}
+}
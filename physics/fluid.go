@@ -0,0 +1,63 @@
+package physics
+
+// Volume of water, or any other fluid, which bodies float and swim in
+type Fluid struct {
+	// How heavy the fluid is compared to the bodies in it
+	// Bodies which are lighter than the fluid float up to the surface
+	Density float64
+
+	// Fraction of the momentum lost each step while fully submerged (ranges from 0.0 to 1.0)
+	Drag float64
+}
+
+// Creates a new fluid
+func NewFluid(density, drag float64) Fluid {
+	fluid := Fluid{}
+
+	fluid.Density = density
+	fluid.Drag = drag
+
+	return fluid
+}
+
+// Splash of a body entering or leaving a fluid, for effects and audio
+type SplashEvent struct {
+	// Entity ids of the body and the fluid
+	Id, Fluid int
+
+	// Where the body crossed the surface of the fluid
+	Point Vector2f
+
+	// Vertical speed of the body when it crossed the surface
+	Speed float64
+
+	// The body entered the fluid, or left it
+	Entered bool
+}
+
+// Returns the fraction of the body inside the volume (ranges from 0.0 to 1.0)
+func (body Body) Submerged(volume Body) float64 {
+	overlap, ok := body.Intersection(volume)
+
+	if !ok {
+		return 0
+	}
+
+	return overlap.Area() / body.Area()
+}
+
+// Pushes the body up and slows it down by the part of it inside the fluid
+// The gravity is the gravity which pulls the body, so bodies without gravity are only slowed down
+// Bodies without a density are only slowed down too
+func (force *Force) ApplyFluid(fluid Fluid, submerged float64, gravity Vector2f, material PhysicsMaterial) {
+	// The fluid pushes the body against the gravity by the weight of the fluid the body moves aside
+	if material.Density > 0 {
+		force.Acceleration = force.Acceleration.Sub(gravity.Scale(fluid.Density / material.Density * submerged))
+	}
+
+	// The fluid slows the body down
+	drag := 1 - fluid.Drag*submerged
+
+	force.Acceleration.X *= drag
+	force.Acceleration.Y *= drag
+}
@@ -8,6 +8,18 @@ import (
 // Collisions are going to be inside force
 type Collisions struct {
 	Left, Right, Up, Down bool
+
+	// Standing on a one-way platform
+	Platform bool
+
+	// Standing on a slope
+	Slope bool
+
+	// Material of the surface which was hit
+	Material PhysicsMaterial
+
+	// Every contact of the step
+	Contacts []Contact
 }
 
 // Movement and collisions
@@ -18,11 +30,23 @@ type Force struct {
 	// Persisting momentum
 	Acceleration Vector2f
 
-	// Maximum horizontal speed
-	Speed float64
+	// Continuous forces applied during this step
+	Push Vector2f
 
 	// Collisions
 	Collisions Collisions
+
+	// Number of steps left to fall through one-way platforms
+	DropThrough int
+
+	// Fraction of the body inside a fluid (ranges from 0.0 to 1.0)
+	Submerged float64
+
+	// Entity id of the fluid the body is in, while it is submerged
+	Fluid int
+
+	// Gravity pulling the body, which is set by the physics step from the gravity zones
+	Gravity Vector2f
 }
 
 // Creates a new force
@@ -32,10 +56,11 @@ func NewForce(velocity, acceleration Vector2f) Force {
 	force.Velocity = velocity
 	force.Acceleration = acceleration
 
-	force.Speed = 3
-
 	force.Collisions = Collisions{}
 
+	force.DropThrough = 0
+	force.Submerged = 0
+
 	return force
 }
 
@@ -52,34 +77,60 @@ func (collisions Collisions) String() string {
 }
 
 // Update the collisions
+// Round shapes can hit surfaces at an angle, so the collision is on the side the normal mostly points away from
+// Surfaces up to 45° steep count as floors and ceilings
 func (collisions *Collisions) Update(contactNormal Vector2f) {
-	if contactNormal.X == 1 {
-		collisions.Left = true
-	} else if contactNormal.X == -1 {
-		collisions.Right = true
-	}
-
-	if contactNormal.Y == 1 {
+	if math.Abs(contactNormal.X) > math.Abs(contactNormal.Y) {
+		if contactNormal.X > 0 {
+			collisions.Left = true
+		} else {
+			collisions.Right = true
+		}
+	} else if contactNormal.Y > 0 {
 		collisions.Up = true
-	} else if contactNormal.Y == -1 {
+	} else if contactNormal.Y < 0 {
 		collisions.Down = true
 	}
 }
 
+// Removes the collisions of the previous step
+// The slice of the contacts is kept, so it does not have to grow again every step
+func (collisions *Collisions) Reset() {
+	*collisions = Collisions{Contacts: collisions.Contacts[:0]}
+}
+
 func (collisions *Collisions) Collided() bool {
 	return collisions.Left || collisions.Right || collisions.Up || collisions.Down
 }
 
-func (force *Force) UpdateGravity() {
+func (force *Force) UpdateGravity(settings PhysicsSettings, material PhysicsMaterial) {
+	force.ApplyGravity(force.Gravity.Scale(material.GravityScale), settings.MaxFallSpeed)
+}
+
+// Pulls the body along the gravity, and limits the speed it falls at along the gravity
+func (force *Force) ApplyGravity(gravity Vector2f, maxFallSpeed float64) {
+	strength := gravity.Magnitude()
+
+	// Bodies without gravity float
+	if strength == 0 {
+		return
+	}
+
 	// Apply gravity
-	force.Acceleration.Y += 0.3
+	force.Acceleration = force.Acceleration.Add(gravity)
 
-	// Limit the gravity to 5
-	force.Acceleration.Y = min(5, force.Acceleration.Y)
+	// Limit the falling speed
+	limit := maxFallSpeed
 
 	// If the body is on the ground, lower the gravity
 	// Don't set it to zero, because, then, the entity is flying
-	if force.Collisions.Down {
-		force.Acceleration.Y = min(0.3, force.Acceleration.Y)
+	if force.Collisions.Grounded(gravity) {
+		limit = min(strength, limit)
+	}
+
+	direction := gravity.Normalize()
+
+	if fall := force.Acceleration.Dot(direction); fall > limit {
+		force.Acceleration = force.Acceleration.Sub(direction.Scale(fall - limit))
 	}
 }
@@ -0,0 +1,97 @@
+package physics
+
+// Hook which attaches to a point, and swings the body on a rope
+type Grapple struct {
+	// The hook is attached to the anchor
+	Attached bool
+
+	// Farthest the hook can be shot, and the shortest the rope can be reeled in to
+	MaxLength float64
+	MinLength float64
+
+	// Change of the length of the rope each step while reeling
+	ReelSpeed float64
+
+	// Number of segments of the rope
+	Segments int
+
+	// Where the hook is attached, and the length of the rope
+	Anchor Vector2f
+	Length float64
+
+	// Rope from the anchor to the body, which hangs around the tiles
+	Rope Verlet
+}
+
+// Creates a new grapple with the default values
+func NewGrapple() Grapple {
+	grapple := Grapple{}
+
+	grapple.Attached = false
+	grapple.MaxLength = 200
+	grapple.MinLength = 16
+	grapple.ReelSpeed = 2
+	grapple.Segments = 12
+
+	return grapple
+}
+
+// Attaches the hook to the anchor, with a rope to the holder
+func (grapple *Grapple) Attach(anchor, holder Vector2f) {
+	grapple.Attached = true
+	grapple.Anchor = anchor
+	grapple.Length = max(grapple.MinLength, anchor.Distance(holder))
+
+	// Both ends of the rope are held
+	grapple.Rope = NewRope(anchor, holder, grapple.Segments)
+	grapple.Rope.AddPin(grapple.Segments, holder)
+}
+
+// Lets go of the anchor
+func (grapple *Grapple) Release() {
+	grapple.Attached = false
+	grapple.Rope = Verlet{}
+}
+
+// Shortens the rope, or lengthens it if the amount is negative
+func (grapple *Grapple) Reel(amount float64) {
+	grapple.Length = min(grapple.MaxLength, max(grapple.MinLength, grapple.Length-amount))
+
+	// The segments of the rope share the length
+	for i, constraint := range grapple.Rope.Constraints {
+		if constraint.Kind == ConstraintDistance {
+			grapple.Rope.Constraints[i].Length = grapple.Length / float64(grapple.Segments)
+		}
+	}
+}
+
+// Changes the momentum of the body so it stays within the length of the rope
+// The momentum towards the anchor is kept, so the body swings around it
+// This MUST be handled BEFORE the momentum is added to the velocity in the physics step
+func (grapple *Grapple) Constrain(body Body, force *Force) {
+	if !grapple.Attached {
+		return
+	}
+
+	center := body.Center()
+	next := center.Add(force.Acceleration)
+
+	offset := next.Sub(grapple.Anchor)
+	if offset.Magnitude() <= grapple.Length {
+		return
+	}
+
+	// Move along the circle around the anchor instead
+	target := grapple.Anchor.Add(offset.Normalize().Scale(grapple.Length))
+	force.Acceleration = target.Sub(center)
+}
+
+// Moves the end of the rope with the holder, and lets the rope hang around the colliders
+func (grapple *Grapple) UpdateRope(holder, gravity Vector2f, colliders []Collider) {
+	if !grapple.Attached {
+		return
+	}
+
+	grapple.Rope.MovePin(grapple.Segments, holder)
+	grapple.Rope.Step(gravity, colliders)
+}
@@ -0,0 +1,158 @@
+package physics
+
+import (
+	"math"
+)
+
+// Area where gravity pulls in another direction, or with another strength
+type GravityZone struct {
+	// Added to the momentum of the bodies in the zone each step
+	Gravity Vector2f
+
+	// Zones with a higher priority override the zones they overlap
+	Priority int
+}
+
+// Creates a new gravity zone
+func NewGravityZone(gravity Vector2f) GravityZone {
+	zone := GravityZone{}
+
+	zone.Gravity = gravity
+	zone.Priority = 0
+
+	return zone
+}
+
+// Returns the number of quarter turns which rotate the gravity to point down
+// Gravity is snapped to the closest side, so that the bodies still collide along the axes
+func gravityTurns(gravity Vector2f) int {
+	switch {
+	case gravity.X > 0 && gravity.X > gravity.Y && gravity.X > -gravity.Y:
+		return 1
+	case gravity.X < 0 && -gravity.X > gravity.Y && -gravity.X > -gravity.Y:
+		return 3
+	case gravity.Y < 0:
+		return 2
+	}
+
+	return 0
+}
+
+// Returns the rotation of the bodies standing against the gravity, in radians
+func GravityRotation(gravity Vector2f) float64 {
+	switch gravityTurns(gravity) {
+	case 1:
+		return -math.Pi / 2
+	case 2:
+		return math.Pi
+	case 3:
+		return math.Pi / 2
+	}
+
+	return 0
+}
+
+// Rotates the vector by quarter turns, without rounding errors
+func (vector Vector2f) rotateQuarter(turns int) Vector2f {
+	for range (turns%4 + 4) % 4 {
+		vector = NewVector2f(-vector.Y, vector.X)
+	}
+
+	return vector
+}
+
+// Rotates the body by quarter turns around (0, 0)
+func (body Body) rotateQuarter(turns int) Body {
+	for range (turns%4 + 4) % 4 {
+		body = NewBody(NewVector2f(-(body.Position.Y+body.Size.Y), body.Position.X), NewVector2f(body.Size.Y, body.Size.X))
+	}
+
+	return body
+}
+
+// Rotates the sides which were hit by quarter turns
+func (collisions Collisions) rotateQuarter(turns int) Collisions {
+	rotated := collisions
+	rotated.Left, rotated.Right, rotated.Up, rotated.Down = false, false, false, false
+
+	sides := []struct {
+		hit  bool
+		side Vector2f
+	}{
+		{collisions.Left, NewVector2f(-1, 0)},
+		{collisions.Right, NewVector2f(1, 0)},
+		{collisions.Up, NewVector2f(0, -1)},
+		{collisions.Down, NewVector2f(0, 1)},
+	}
+
+	for _, side := range sides {
+		if !side.hit {
+			continue
+		}
+
+		switch side.side.rotateQuarter(turns) {
+		case NewVector2f(-1, 0):
+			rotated.Left = true
+		case NewVector2f(1, 0):
+			rotated.Right = true
+		case NewVector2f(0, -1):
+			rotated.Up = true
+		case NewVector2f(0, 1):
+			rotated.Down = true
+		}
+	}
+
+	return rotated
+}
+
+// Rotates the momentum and collisions of the force by quarter turns
+func (force Force) rotateQuarter(turns int) Force {
+	force.Velocity = force.Velocity.rotateQuarter(turns)
+	force.Acceleration = force.Acceleration.rotateQuarter(turns)
+	force.Push = force.Push.rotateQuarter(turns)
+	force.Gravity = force.Gravity.rotateQuarter(turns)
+	force.Collisions = force.Collisions.rotateQuarter(turns)
+
+	return force
+}
+
+// Rotates the held directions by quarter turns, so they still point the same way on the screen
+func (input ControllerInput) rotateQuarter(turns int) ControllerInput {
+	collisions := Collisions{Left: input.Left, Right: input.Right, Up: input.Up, Down: input.Down}.rotateQuarter(turns)
+
+	input.Left = collisions.Left
+	input.Right = collisions.Right
+	input.Up = collisions.Up
+	input.Down = collisions.Down
+
+	return input
+}
+
+// Rotates the bodies of the colliders by quarter turns around the origin
+func rotateColliders(colliders []Collider, origin Vector2f, turns int) []Collider {
+	rotated := make([]Collider, len(colliders))
+
+	for i, collider := range colliders {
+		rotated[i] = collider
+		rotated[i].Body = collider.Body.Translate(origin.Neg()).rotateQuarter(turns)
+		rotated[i].Velocity = collider.Velocity.rotateQuarter(turns)
+	}
+
+	return rotated
+}
+
+// Runs the update on the force as if its gravity pointed down
+func (force *Force) withGravityDown(update func(local *Force)) {
+	turns := gravityTurns(force.Gravity)
+
+	local := force.rotateQuarter(turns)
+	update(&local)
+
+	*force = local.rotateQuarter(-turns)
+}
+
+// Returns true if the body stands on the side the gravity pulls it to
+// Bodies without gravity stand on the bottom
+func (collisions Collisions) Grounded(gravity Vector2f) bool {
+	return collisions.rotateQuarter(gravityTurns(gravity)).Down
+}
@@ -0,0 +1,141 @@
+package physics
+
+const (
+	// How a radial impulse weakens towards the edge of its radius
+	FalloffNone int = iota
+	FalloffLinear
+	FalloffQuadratic
+)
+
+// Push outwards from a point, which hits every body in its radius
+type RadialImpulse struct {
+	Center Vector2f
+	Radius float64
+
+	// Impulse at the center
+	Strength float64
+
+	// How the impulse weakens towards the edge of the radius
+	Falloff int
+
+	// Upwards impulse added to every body which is hit, so bodies on the ground are launched
+	Lift float64
+
+	// Layers of the bodies which are pushed
+	Mask uint32
+
+	// Layers which block the impulse, so the bodies have to be in the line of sight of the center
+	// Nothing blocks the impulse if the mask is 0
+	BlockMask uint32
+}
+
+// Creates a new radial impulse which pushes every layer, and weakens linearly
+func NewRadialImpulse(center Vector2f, radius, strength float64) RadialImpulse {
+	impulse := RadialImpulse{}
+
+	impulse.Center = center
+	impulse.Radius = radius
+	impulse.Strength = strength
+	impulse.Falloff = FalloffLinear
+	impulse.Lift = 0
+	impulse.Mask = LayerAll
+	impulse.BlockMask = 0
+
+	return impulse
+}
+
+// Impulse given to a collider
+type ImpulseHit struct {
+	// Entity id of the collider
+	Id int
+
+	Impulse Vector2f
+}
+
+// Returns the fraction of the strength at the distance from the center (ranges from 0.0 to 1.0)
+func (impulse RadialImpulse) falloff(distance float64) float64 {
+	return falloff(impulse.Falloff, distance, impulse.Radius)
+}
+
+// Returns the fraction of the strength at the distance from the center of the radius (ranges from 0.0 to 1.0)
+func falloff(kind int, distance, radius float64) float64 {
+	t := min(1, distance/radius)
+
+	switch kind {
+	case FalloffLinear:
+		return 1 - t
+	case FalloffQuadratic:
+		return (1 - t) * (1 - t)
+	}
+
+	return 1
+}
+
+// Returns the impulse given to each collider in the radius
+// The distance is measured to the closest point of each collider
+func (world *World) RadialImpulse(impulse RadialImpulse) []ImpulseHit {
+	hits := make([]ImpulseHit, 0)
+
+	world.Debug.AddCircle(NewCircle(impulse.Center, impulse.Radius))
+
+	// Only check the colliders around the circle
+	area := NewBody(impulse.Center, NewVector2f(0, 0)).Expand(NewVector2f(impulse.Radius, impulse.Radius))
+
+	for _, index := range world.nearbyIndices(area, impulse.Mask) {
+		collider := world.Colliders[index]
+
+		distance := distanceToBody(collider.Body, impulse.Center)
+		if distance > impulse.Radius {
+			continue
+		}
+
+		direction := collider.Body.Center().Sub(impulse.Center)
+
+		// Skip the colliders behind the blocking layers
+		if impulse.BlockMask != 0 {
+			if _, blocked := world.RaycastFirst(impulse.Center, direction, impulse.BlockMask); blocked {
+				continue
+			}
+		}
+
+		// Push away from the center, or upwards if the center is the center of the collider
+		if direction.Magnitude() == 0 {
+			direction = NewVector2f(0, -1)
+		} else {
+			direction = direction.Normalize()
+		}
+
+		strength := impulse.Strength * impulse.falloff(distance)
+
+		hits = append(hits, ImpulseHit{
+			Id:      collider.Id,
+			Impulse: direction.Scale(strength).Sub(NewVector2f(0, impulse.Lift)),
+		})
+	}
+
+	return hits
+}
+
+// Changes the momentum of the body at once
+// Heavier bodies are pushed less
+func (force *Force) ApplyImpulse(impulse Vector2f, material PhysicsMaterial) {
+	// Bodies without a mass can't be pushed
+	if material.Mass <= 0 {
+		return
+	}
+
+	force.Acceleration = force.Acceleration.Add(impulse.Scale(1 / material.Mass))
+}
+
+// Pushes the body during this step
+// Continuous forces have to be applied every step, and are added to the momentum in the physics step
+func (force *Force) ApplyForce(push Vector2f) {
+	force.Push = force.Push.Add(push)
+}
+
+// Adds the continuous forces of this step to the momentum
+func (force *Force) IntegrateForces(material PhysicsMaterial) {
+	force.ApplyImpulse(force.Push, material)
+
+	force.Push = NewVector2f(0, 0)
+}
@@ -0,0 +1,138 @@
+package physics
+
+// How a body or a surface behaves in the physics step
+type PhysicsMaterial struct {
+	// How much horizontal momentum is lost each step
+	Friction float64
+
+	// How much speed is kept when bouncing off a surface (ranges from 0.0 to 1.0)
+	Restitution float64
+
+	// Fraction of the momentum lost each step while in the air (ranges from 0.0 to 1.0)
+	AirDrag float64
+
+	// Multiplier of the world gravity
+	GravityScale float64
+
+	// How heavy the body is, compared to the density of the fluids it is in
+	Density float64
+
+	// How hard the body is to push
+	Mass float64
+
+	// Speed the surface carries the bodies standing on it at, like a conveyor belt
+	// The speed is along the surface, where positive speeds turn clockwise around the collider
+	SurfaceSpeed float64
+}
+
+// Creates a new material with the default values
+func NewPhysicsMaterial() PhysicsMaterial {
+	material := PhysicsMaterial{}
+
+	material.Friction = 0.6
+	material.Restitution = 0
+	material.AirDrag = 0
+	material.GravityScale = 1
+	material.Density = 1
+	material.Mass = 1
+	material.SurfaceSpeed = 0
+
+	return material
+}
+
+// Values shared by every body in the world
+type PhysicsSettings struct {
+	// Added to the momentum each step, outside of the gravity zones
+	Gravity Vector2f
+
+	// Fastest speed a body can fall at
+	MaxFallSpeed float64
+
+	// Added to the horizontal momentum each step while moving
+	MoveAcceleration float64
+
+	// Fastest speed a body can move at by itself
+	MoveSpeed float64
+
+	// Slowest speed which bounces off a surface
+	// Slower bodies come to rest, so they don't jitter on the ground
+	BounceThreshold float64
+
+	// Material of the bodies and tiles which do not have one
+	DefaultMaterial PhysicsMaterial
+
+	// Snap the bodies onto a grid after each step, so runs with the same input give the same results
+	Deterministic bool
+
+	// Size of the grid in determinism mode
+	Quantum float64
+
+	// Slowest speed which counts as moving, and the number of steps at rest before a dynamic body sleeps
+	SleepSpeed float64
+	SleepTime  int
+
+	// Distance an attack knocks the enemies back from, its strength, and how high it launches them
+	KnockbackRadius   float64
+	KnockbackStrength float64
+	KnockbackLift     float64
+
+	// Radius and strength of the explosion of a projectile
+	ExplosionRadius   float64
+	ExplosionStrength float64
+}
+
+// Creates new settings with the default values
+func NewPhysicsSettings() PhysicsSettings {
+	settings := PhysicsSettings{}
+
+	settings.Gravity = NewVector2f(0, 0.3)
+	settings.MaxFallSpeed = 5
+	settings.MoveAcceleration = 0.9
+	settings.MoveSpeed = 3
+	settings.BounceThreshold = 1
+	settings.DefaultMaterial = NewPhysicsMaterial()
+	settings.Deterministic = false
+	settings.Quantum = 1.0 / 1024
+	settings.SleepSpeed = 0.01
+	settings.SleepTime = 30
+	settings.KnockbackRadius = 80
+	settings.KnockbackStrength = 6
+	settings.KnockbackLift = 4
+	settings.ExplosionRadius = 32
+	settings.ExplosionStrength = 9
+
+	return settings
+}
+
+// Records the material of the surface which was hit
+// The ground decides the friction, so it takes priority over the walls and ceilings
+// The ground is the side the gravity pulls the body to
+func (collisions *Collisions) UpdateMaterial(material PhysicsMaterial, contactNormal Vector2f, gravity Vector2f) {
+	normal := contactNormal.rotateQuarter(gravityTurns(gravity))
+
+	if !collisions.Grounded(gravity) || -normal.Y >= max(normal.X, -normal.X) {
+		collisions.Material = material
+	}
+}
+
+// Bounces the body off the surfaces it hit
+// The bounciest of the body and the surface is used
+func (force *Force) Bounce(settings PhysicsSettings, material PhysicsMaterial) {
+	restitution := max(material.Restitution, force.Collisions.Material.Restitution)
+
+	if restitution == 0 {
+		return
+	}
+
+	// Vertical bounces
+	if (force.Collisions.Down && force.Acceleration.Y > settings.BounceThreshold) ||
+		(force.Collisions.Up && force.Acceleration.Y < -settings.BounceThreshold) {
+		force.Acceleration.Y *= -restitution
+	}
+
+	// Horizontal bounces
+	if (force.Collisions.Right && force.Acceleration.X > settings.BounceThreshold) ||
+		(force.Collisions.Left && force.Acceleration.X < -settings.BounceThreshold) {
+		force.Acceleration.X *= -restitution
+	}
+}
@@ -0,0 +1,152 @@
+package physics
+
+import (
+	"math"
+)
+
+const (
+	// Path modes
+	// Loop paths go back to the first waypoint after the last one
+	PathLoop int = iota
+	// Ping-pong paths go back and forth through the waypoints
+	PathPingPong
+	// Triggered paths go back and forth, but wait at both ends until they are triggered
+	PathTriggered
+)
+
+// Waypoint path followed by kinematic bodies, such as moving platforms
+type Path struct {
+	// Positions the body moves through
+	Waypoints []Vector2f
+
+	// How the path continues after the last waypoint
+	Mode int
+
+	// Distance moved every step
+	Speed float64
+
+	// Index of the waypoint the body is moving towards
+	Target int
+
+	// Direction the waypoints are gone through (1 or -1)
+	Direction int
+
+	// Triggered paths wait until they are triggered
+	Waiting bool
+
+	// Movement in the last step
+	Delta Vector2f
+}
+
+// Creates a new path which starts at the first waypoint
+func NewPath(waypoints []Vector2f, mode int, speed float64) Path {
+	path := Path{}
+
+	path.Waypoints = waypoints
+	path.Mode = mode
+	path.Speed = speed
+
+	// The body starts at the first waypoint, so it moves towards the second
+	path.Target = 1
+	path.Direction = 1
+
+	path.Waiting = mode == PathTriggered
+
+	path.Delta = NewVector2f(0, 0)
+
+	return path
+}
+
+// Starts a triggered path
+func (path *Path) Trigger() {
+	path.Waiting = false
+}
+
+// Moves the body along the path and returns its movement
+func (path *Path) Update(body *Body) Vector2f {
+	path.Delta = NewVector2f(0, 0)
+
+	// The body needs at least two waypoints to move between
+	if len(path.Waypoints) < 2 || path.Waiting {
+		return path.Delta
+	}
+
+	// Distance to the target waypoint
+	target := path.Waypoints[path.Target]
+	distance := target.Sub(body.Position)
+
+	if distance.Magnitude() <= path.Speed {
+		// Stop at the waypoint, and move on to the next
+		path.Delta = distance
+		path.next()
+	} else {
+		// Move towards the waypoint at the speed of the path
+		scale := path.Speed / distance.Magnitude()
+		path.Delta = distance.Scale(scale)
+	}
+
+	body.Position = body.Position.Add(path.Delta)
+
+	return path.Delta
+}
+
+// Choose the next waypoint to move towards
+func (path *Path) next() {
+	if path.Mode == PathLoop {
+		path.Target = (path.Target + 1) % len(path.Waypoints)
+		return
+	}
+
+	// Turn around at either end of the path
+	next := path.Target + path.Direction
+	if next < 0 || next >= len(path.Waypoints) {
+		path.Direction = -path.Direction
+		next = path.Target + path.Direction
+
+		// Triggered paths wait at the ends
+		if path.Mode == PathTriggered {
+			path.Waiting = true
+		}
+	}
+
+	path.Target = next
+}
+
+// Returns true if the body is standing on top of the platform
+func (body Body) RidesOn(platform Body) bool {
+	// The feet have to be on the top of the platform
+	feet := body.Position.Y + body.Size.Y
+	if math.Abs(feet-platform.Position.Y) > collisionEpsilon {
+		return false
+	}
+
+	// The body has to be above the platform
+	return body.Position.X < platform.Position.X+platform.Size.X && body.Position.X+body.Size.X > platform.Position.X
+}
+
+// Returns how far the body has to be pushed to get out of the way of the moving platform
+func (platform Body) PushOut(body Body, delta Vector2f) Vector2f {
+	push := NewVector2f(0, 0)
+
+	// Push the body out of the side the platform is moving towards
+	if delta.X > 0 {
+		push.X = platform.Position.X + platform.Size.X - body.Position.X
+	} else if delta.X < 0 {
+		push.X = platform.Position.X - (body.Position.X + body.Size.X)
+	}
+
+	if delta.Y > 0 {
+		push.Y = platform.Position.Y + platform.Size.Y - body.Position.Y
+	} else if delta.Y < 0 {
+		push.Y = platform.Position.Y - (body.Position.Y + body.Size.Y)
+	}
+
+	// Only push along the axis which needs the smallest push
+	if push.X != 0 && (push.Y == 0 || math.Abs(push.X) <= math.Abs(push.Y)) {
+		push.Y = 0
+	} else {
+		push.X = 0
+	}
+
+	return push
+}
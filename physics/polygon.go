@@ -0,0 +1,295 @@
+package physics
+
+import (
+	"math"
+	"sort"
+)
+
+// Returns the outline of the collider as a convex polygon
+func (collider Collider) Polygon() []Vector2f {
+	body := collider.Body
+
+	left := body.Position.X
+	right := body.Position.X + body.Size.X
+	top := body.Position.Y
+	bottom := body.Position.Y + body.Size.Y
+
+	// Rectangles
+	if collider.Slope == SlopeNone {
+		return []Vector2f{
+			NewVector2f(left, top),
+			NewVector2f(right, top),
+			NewVector2f(right, bottom),
+			NewVector2f(left, bottom),
+		}
+	}
+
+	heightLeft, heightRight := collider.Slope.Heights()
+
+	var points []Vector2f
+
+	if collider.Slope.Ceiling() {
+		points = []Vector2f{
+			NewVector2f(left, top),
+			NewVector2f(right, top),
+			NewVector2f(right, top+heightRight*body.Size.Y),
+			NewVector2f(left, top+heightLeft*body.Size.Y),
+		}
+	} else {
+		points = []Vector2f{
+			NewVector2f(left, bottom-heightLeft*body.Size.Y),
+			NewVector2f(right, bottom-heightRight*body.Size.Y),
+			NewVector2f(right, bottom),
+			NewVector2f(left, bottom),
+		}
+	}
+
+	// 45° slopes are triangles, so remove the corner with no height
+	return removeDuplicatePoints(points)
+}
+
+// Removes the points which are the same as the point before them
+func removeDuplicatePoints(points []Vector2f) []Vector2f {
+	result := make([]Vector2f, 0, len(points))
+
+	for i, point := range points {
+		previous := points[(i+len(points)-1)%len(points)]
+
+		if point != previous {
+			result = append(result, point)
+		}
+	}
+
+	return result
+}
+
+// Returns the normal of each edge of the polygon, pointing out of the polygon
+func polygonNormals(points []Vector2f) []Vector2f {
+	// The center is always inside a convex polygon
+	var center Vector2f
+	for _, point := range points {
+		center.X += point.X / float64(len(points))
+		center.Y += point.Y / float64(len(points))
+	}
+
+	normals := make([]Vector2f, len(points))
+
+	for i, point := range points {
+		next := points[(i+1)%len(points)]
+
+		// Perpendicular to the edge
+		normal := NewVector2f(next.Y-point.Y, point.X-next.X)
+		normal = normal.Normalize()
+
+		// Flip the normal if it points towards the center
+		if normal.Dot(center.Sub(point)) > 0 {
+			normal = normal.Neg()
+		}
+
+		normals[i] = normal
+	}
+
+	return normals
+}
+
+// Where hit time is the time taken to hit the polygon (hitTime ranges from 0.0 to 1.0)
+// Rays which start inside the polygon do not collide with it
+func RayVsPolygon(points []Vector2f, start, velocity Vector2f) (collision bool, hitTime float64, contactNormal Vector2f) {
+	normals := polygonNormals(points)
+
+	// The ray is clipped by every edge of the polygon
+	// The ray enters the polygon at the last time it crosses into an edge,
+	// and leaves the polygon at the first time it crosses out of an edge
+	enter := math.Inf(-1)
+	exit := math.Inf(1)
+
+	for i, point := range points {
+		normal := normals[i]
+
+		// Distance from the start to the edge, and how fast the ray moves towards the edge
+		distance := normal.X*(point.X-start.X) + normal.Y*(point.Y-start.Y)
+		speed := normal.X*velocity.X + normal.Y*velocity.Y
+
+		if speed == 0 {
+			// The ray is parallel to the edge, and outside of it
+			if distance < 0 {
+				return false, 1.0, NewVector2f(0, 0)
+			}
+
+			continue
+		}
+
+		time := distance / speed
+
+		if speed < 0 {
+			// Crossing into the edge
+			if time > enter {
+				enter = time
+				contactNormal = normal
+			}
+		} else {
+			// Crossing out of the edge
+			exit = min(exit, time)
+		}
+	}
+
+	// The ray misses the polygon, starts inside it, or stops before it
+	// A tiny negative enter time is allowed, so that resting bodies don't sink through floating point errors
+	if enter > exit || enter < -collisionEpsilon || enter > 1 {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	return true, max(0, enter), contactNormal
+}
+
+// Returns true if the polygon and the body overlap
+// Uses the separating axis theorem, where two convex shapes do not overlap if there is a line between them
+func PolygonVsBody(points []Vector2f, body Body) bool {
+	corners := []Vector2f{
+		body.Position,
+		NewVector2f(body.Position.X+body.Size.X, body.Position.Y),
+		NewVector2f(body.Position.X+body.Size.X, body.Position.Y+body.Size.Y),
+		NewVector2f(body.Position.X, body.Position.Y+body.Size.Y),
+	}
+
+	// The axes of the body and the normals of the polygon
+	axes := append([]Vector2f{NewVector2f(1, 0), NewVector2f(0, 1)}, polygonNormals(points)...)
+
+	for _, axis := range axes {
+		minimumA, maximumA := project(points, axis)
+		minimumB, maximumB := project(corners, axis)
+
+		// Touching edges do not count as overlapping
+		if maximumA <= minimumB || maximumB <= minimumA {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if the polygon and the circle overlap
+func PolygonVsCircle(points []Vector2f, center Vector2f, radius float64) bool {
+	normals := polygonNormals(points)
+
+	// The center is inside the polygon if it is behind every edge
+	inside := true
+
+	for i, point := range points {
+		if normals[i].X*(center.X-point.X)+normals[i].Y*(center.Y-point.Y) > 0 {
+			inside = false
+			break
+		}
+	}
+
+	if inside {
+		return true
+	}
+
+	// Otherwise, the closest edge has to be within the radius
+	for i, point := range points {
+		next := points[(i+1)%len(points)]
+
+		closest := closestPointOnSegment(point, next, center)
+		if hypot(center.X-closest.X, center.Y-closest.Y) < radius {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the point on the line segment which is closest to the point
+func closestPointOnSegment(start, end, point Vector2f) Vector2f {
+	segment := end.Sub(start)
+
+	lengthSquared := segment.Dot(segment)
+	if lengthSquared == 0 {
+		return start
+	}
+
+	// How far along the segment the point is (ranges from 0.0 to 1.0)
+	t := point.Sub(start).Dot(segment) / lengthSquared
+	t = max(0, min(1, t))
+
+	return start.Add(segment.Scale(t))
+}
+
+// Returns the range the points cover along the axis
+func project(points []Vector2f, axis Vector2f) (minimum, maximum float64) {
+	minimum = math.Inf(1)
+	maximum = math.Inf(-1)
+
+	for _, point := range points {
+		distance := point.Dot(axis)
+
+		minimum = min(minimum, distance)
+		maximum = max(maximum, distance)
+	}
+
+	return minimum, maximum
+}
+
+// Grows the polygon by the size of the body, centered on the body
+// Sweeping the body against the polygon is the same as casting a ray from its center against the grown polygon
+func (body Body) ExpandPolygon(points []Vector2f) []Vector2f {
+	halfSize := body.Size.Scale(0.5)
+
+	expanded := make([]Vector2f, 0, len(points)*4)
+
+	for _, point := range points {
+		expanded = append(expanded,
+			NewVector2f(point.X-halfSize.X, point.Y-halfSize.Y),
+			NewVector2f(point.X+halfSize.X, point.Y-halfSize.Y),
+			NewVector2f(point.X+halfSize.X, point.Y+halfSize.Y),
+			NewVector2f(point.X-halfSize.X, point.Y+halfSize.Y),
+		)
+	}
+
+	return convexHull(expanded)
+}
+
+// Returns the smallest convex polygon which contains all the points
+// Uses the monotone chain algorithm
+func convexHull(points []Vector2f) []Vector2f {
+	sorted := append([]Vector2f{}, points...)
+
+	sort.Slice(sorted, func(a, b int) bool {
+		if sorted[a].X == sorted[b].X {
+			return sorted[a].Y < sorted[b].Y
+		}
+
+		return sorted[a].X < sorted[b].X
+	})
+
+	// Returns a positive value if o, a, b turn counter-clockwise
+	cross := func(o, a, b Vector2f) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+
+	hull := make([]Vector2f, 0, len(sorted)*2)
+
+	// Lower hull
+	for _, point := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, point)
+	}
+
+	// Upper hull
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		point := sorted[i]
+
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, point)
+	}
+
+	// The last point is the same as the first point
+	return hull[:len(hull)-1]
+}
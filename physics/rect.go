@@ -0,0 +1,69 @@
+package physics
+
+// Bodies are axis aligned rectangles, so the rectangle functions work on the bodies
+// The areas they return are bodies too, so they can be used without converting them
+
+// Creates a new body from its top left and bottom right corners
+func NewBodyFromCorners(minimum, maximum Vector2f) Body {
+	return NewBody(minimum, maximum.Sub(minimum))
+}
+
+// Returns the top left corner
+func (body Body) Min() Vector2f {
+	return body.Position
+}
+
+// Returns the bottom right corner
+func (body Body) Max() Vector2f {
+	return body.Position.Add(body.Size)
+}
+
+// Returns the body moved by the offset
+func (body Body) Translate(offset Vector2f) Body {
+	body.Position = body.Position.Add(offset)
+
+	return body
+}
+
+// Returns the body grown by the margin on every side
+// Negative margins shrink the body
+func (body Body) Expand(margin Vector2f) Body {
+	return NewBody(body.Position.Sub(margin), body.Size.Add(margin.Scale(2)))
+}
+
+// Returns the smallest body which covers both bodies
+func (bodyA Body) Union(bodyB Body) Body {
+	minimum := NewVector2f(min(bodyA.Position.X, bodyB.Position.X), min(bodyA.Position.Y, bodyB.Position.Y))
+	maximum := NewVector2f(max(bodyA.Max().X, bodyB.Max().X), max(bodyA.Max().Y, bodyB.Max().Y))
+
+	return NewBodyFromCorners(minimum, maximum)
+}
+
+// Returns the area covered by both bodies
+// Returns false if the bodies do not overlap
+func (bodyA Body) Intersection(bodyB Body) (Body, bool) {
+	minimum := NewVector2f(max(bodyA.Position.X, bodyB.Position.X), max(bodyA.Position.Y, bodyB.Position.Y))
+	maximum := NewVector2f(min(bodyA.Max().X, bodyB.Max().X), min(bodyA.Max().Y, bodyB.Max().Y))
+
+	if maximum.X <= minimum.X || maximum.Y <= minimum.Y {
+		return Body{}, false
+	}
+
+	return NewBodyFromCorners(minimum, maximum), true
+}
+
+// Returns true if the point is inside the body or on its edge
+func (body Body) Contains(point Vector2f) bool {
+	return point.X >= body.Position.X && point.X <= body.Position.X+body.Size.X &&
+		point.Y >= body.Position.Y && point.Y <= body.Position.Y+body.Size.Y
+}
+
+// Returns true if body B is completely inside body A
+func (bodyA Body) ContainsBody(bodyB Body) bool {
+	return bodyA.Contains(bodyB.Min()) && bodyA.Contains(bodyB.Max())
+}
+
+// Returns the area of the body
+func (body Body) Area() float64 {
+	return body.Size.X * body.Size.Y
+}
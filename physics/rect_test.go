@@ -0,0 +1,103 @@
+package physics
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// Returns a random body which is at least 1 by 1
+// The corners are whole numbers, like the tiles, so the sums of the positions and sizes are exact
+func randomBody(random *rand.Rand) Body {
+	size := NewVector2f(float64(random.IntN(50)+1), float64(random.IntN(50)+1))
+
+	return NewBody(randomPoint(random), size)
+}
+
+// Returns a random point with whole co-ordinates between -50 and 50
+func randomPoint(random *rand.Rand) Vector2f {
+	return NewVector2f(float64(random.IntN(101)-50), float64(random.IntN(101)-50))
+}
+
+func TestUnion(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		a, b := randomBody(random), randomBody(random)
+
+		union := a.Union(b)
+
+		// The union covers both rectangles
+		if !union.ContainsBody(a) || !union.ContainsBody(b) {
+			t.Fatalf("union %v of %v and %v does not contain both", union, a, b)
+		}
+
+		// The union is the same either way around
+		if union != b.Union(a) {
+			t.Fatalf("union of %v and %v depends on the order", a, b)
+		}
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		a, b := randomBody(random), randomBody(random)
+
+		intersection, ok := a.Intersection(b)
+
+		// Rectangles only intersect if they overlap
+		if ok != a.CollidesWithStaticBody(b) {
+			t.Fatalf("intersection of %v and %v = %v, but they overlap = %v", a, b, ok, a.CollidesWithStaticBody(b))
+		}
+
+		if !ok {
+			continue
+		}
+
+		// The intersection is inside both rectangles and their union
+		if !a.ContainsBody(intersection) || !b.ContainsBody(intersection) || !a.Union(b).ContainsBody(intersection) {
+			t.Fatalf("intersection %v of %v and %v is outside of them", intersection, a, b)
+		}
+
+		// A point is in the intersection if it is in both rectangles
+		point := randomPoint(random)
+
+		if intersection.Contains(point) != (a.Contains(point) && b.Contains(point)) {
+			t.Fatalf("point %v in intersection %v = %v, in %v and %v = %v",
+				point, intersection, intersection.Contains(point), a, b, a.Contains(point) && b.Contains(point))
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		rect := randomBody(random)
+		margin := NewVector2f(random.Float64()*10, random.Float64()*10)
+
+		expanded := rect.Expand(margin)
+
+		// Growing keeps the rectangle inside, and shrinking back gives the same rectangle
+		if !expanded.ContainsBody(rect) {
+			t.Fatalf("%v grown by %v = %v, which does not contain it", rect, margin, expanded)
+		}
+
+		if shrunk := expanded.Expand(margin.Neg()); shrunk.Min().Distance(rect.Min()) > 1e-9 || shrunk.Max().Distance(rect.Max()) > 1e-9 {
+			t.Fatalf("%v grown and shrunk by %v = %v", rect, margin, shrunk)
+		}
+
+		// The margin is added on every side
+		if got := expanded.Area(); got < rect.Area() {
+			t.Fatalf("%v grown by %v has a smaller area %v", rect, margin, got)
+		}
+
+		// A point just past the edge is in the grown rectangle, but not in the rectangle
+		edge := NewVector2f(rect.Max().X+margin.X/2, rect.Center().Y)
+
+		if margin.X > 0 && (!expanded.Contains(edge) || rect.Contains(edge)) {
+			t.Fatalf("point %v past the edge of %v is not only in the grown %v", edge, rect, expanded)
+		}
+	}
+}
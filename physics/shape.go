@@ -0,0 +1,450 @@
+package physics
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Collision shapes
+// Every shape is a rectangle grown by a radius (a rectangle with rounded corners),
+// which makes the tests between any two shapes the same test
+type Shape interface {
+	// Returns the inner rectangle and the radius it is grown by
+	Rounded() (core Body, radius float64)
+}
+
+// Axis aligned rectangle
+type AABB struct {
+	Body Body
+}
+
+func NewAABB(body Body) AABB {
+	return AABB{body}
+}
+
+func (aabb AABB) Rounded() (Body, float64) {
+	return aabb.Body, 0
+}
+
+// Circle around a center point
+type Circle struct {
+	Center Vector2f
+	Radius float64
+}
+
+func NewCircle(center Vector2f, radius float64) Circle {
+	return Circle{center, radius}
+}
+
+func (circle Circle) Rounded() (Body, float64) {
+	return NewBody(circle.Center, NewVector2f(0, 0)), circle.Radius
+}
+
+// Line segment grown by a radius
+// The segment has to be horizontal or vertical, since the shapes are rectangles grown by a radius
+type Capsule struct {
+	Start, End Vector2f
+	Radius     float64
+}
+
+// Returns an error if the segment is diagonal
+func NewCapsule(start, end Vector2f, radius float64) (Capsule, error) {
+	if start.X != end.X && start.Y != end.Y {
+		return Capsule{}, errors.New("capsule segment has to be horizontal or vertical")
+	}
+
+	return Capsule{start, end, radius}, nil
+}
+
+func (capsule Capsule) Rounded() (Body, float64) {
+	core := NewBody(
+		NewVector2f(min(capsule.Start.X, capsule.End.X), min(capsule.Start.Y, capsule.End.Y)),
+		NewVector2f(math.Abs(capsule.End.X-capsule.Start.X), math.Abs(capsule.End.Y-capsule.Start.Y)),
+	)
+
+	return core, capsule.Radius
+}
+
+// Rectangle with rounded corners
+type RoundedBox struct {
+	Body   Body
+	Radius float64
+}
+
+// The radius is at most half of the shorter side
+func NewRoundedBox(body Body, radius float64) RoundedBox {
+	return RoundedBox{body, min(radius, body.Size.X/2, body.Size.Y/2)}
+}
+
+func (box RoundedBox) Rounded() (Body, float64) {
+	return box.Body.Expand(NewVector2f(-box.Radius, -box.Radius)), box.Radius
+}
+
+// Returns the smallest body which contains the shape
+func Bounds(shape Shape) Body {
+	core, radius := shape.Rounded()
+
+	return core.Expand(NewVector2f(radius, radius))
+}
+
+// Returns the center of the shape
+func ShapeCenter(shape Shape) Vector2f {
+	core, _ := shape.Rounded()
+
+	return core.Center()
+}
+
+// Returns true if the two shapes overlap
+func Overlaps(shapeA, shapeB Shape) bool {
+	// Shape A overlaps shape B, if the center of A is inside B grown by A
+	core, radius := minkowski(shapeA, shapeB)
+	center := ShapeCenter(shapeA)
+
+	// Rectangles have no radius, so touching edges do not count as overlapping
+	if radius == 0 {
+		return pointInside(core, center)
+	}
+
+	return distanceToBody(core, center) < radius
+}
+
+// Returns true if shape A moving with the velocity hits shape B during this step
+// Where hit time is the fraction of the velocity travelled before the hit (hitTime ranges from 0.0 to 1.0)
+func Sweep(shapeA Shape, velocity Vector2f, shapeB Shape) (collision bool, hitTime float64, contactNormal Vector2f) {
+	// Sweeping shape A against shape B is the same as casting a ray from the center of A against B grown by A
+	core, radius := minkowski(shapeA, shapeB)
+
+	return RayVsRounded(core, radius, ShapeCenter(shapeA), velocity)
+}
+
+// Returns shape B grown by the size of shape A
+func minkowski(shapeA, shapeB Shape) (Body, float64) {
+	coreA, radiusA := shapeA.Rounded()
+	coreB, radiusB := shapeB.Rounded()
+
+	core := NewBody(
+		NewVector2f(coreB.Position.X-coreA.Size.X/2, coreB.Position.Y-coreA.Size.Y/2),
+		NewVector2f(coreB.Size.X+coreA.Size.X, coreB.Size.Y+coreA.Size.Y),
+	)
+
+	return core, radiusA + radiusB
+}
+
+// Returns the distance from the point to the closest point of the body
+func distanceToBody(body Body, point Vector2f) float64 {
+	closest := NewVector2f(
+		max(body.Position.X, min(body.Position.X+body.Size.X, point.X)),
+		max(body.Position.Y, min(body.Position.Y+body.Size.Y, point.Y)),
+	)
+
+	return hypot(point.X-closest.X, point.Y-closest.Y)
+}
+
+// Returns true if the point is strictly inside the body
+func pointInside(body Body, point Vector2f) bool {
+	return point.X > body.Position.X && point.X < body.Position.X+body.Size.X &&
+		point.Y > body.Position.Y && point.Y < body.Position.Y+body.Size.Y
+}
+
+// Where hit time is the time taken to hit the rounded rectangle (hitTime ranges from 0.0 to 1.0)
+// Rays which start inside the rounded rectangle do not collide with it
+func RayVsRounded(core Body, radius float64, start, velocity Vector2f) (collision bool, hitTime float64, contactNormal Vector2f) {
+	// A stationary ray cannot hit anything
+	if velocity.X == 0 && velocity.Y == 0 {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	// Rays which start inside do not collide
+	// A tiny overlap is allowed, so that resting shapes don't sink through floating point errors
+	if radius > 0 && distanceToBody(core, start) < radius-collisionEpsilon {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	// The rounded rectangle is made of a wide rectangle, a tall rectangle and a circle on each corner
+	hitTime = math.Inf(1)
+
+	pieces := []Body{
+		NewBody(NewVector2f(core.Position.X-radius, core.Position.Y), NewVector2f(core.Size.X+radius*2, core.Size.Y)),
+		NewBody(NewVector2f(core.Position.X, core.Position.Y-radius), NewVector2f(core.Size.X, core.Size.Y+radius*2)),
+	}
+
+	for _, piece := range pieces {
+		// Skip the pieces with no area
+		if piece.Size.X == 0 || piece.Size.Y == 0 {
+			continue
+		}
+
+		hit, time, normal := piece.VsRay(start, velocity)
+
+		if hit && time >= -collisionEpsilon && time < 1 && time < hitTime {
+			hitTime = time
+			contactNormal = normal
+		}
+	}
+
+	if radius > 0 {
+		corners := []Vector2f{
+			core.Position,
+			NewVector2f(core.Position.X+core.Size.X, core.Position.Y),
+			NewVector2f(core.Position.X+core.Size.X, core.Position.Y+core.Size.Y),
+			NewVector2f(core.Position.X, core.Position.Y+core.Size.Y),
+		}
+
+		for _, corner := range corners {
+			hit, time, normal := RayVsCircle(corner, radius, start, velocity)
+
+			if hit && time < hitTime {
+				hitTime = time
+				contactNormal = normal
+			}
+		}
+	}
+
+	if math.IsInf(hitTime, 1) {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	return true, max(0, hitTime), contactNormal
+}
+
+// Where hit time is the time taken to hit the circle (hitTime ranges from 0.0 to 1.0)
+func RayVsCircle(center Vector2f, radius float64, start, velocity Vector2f) (collision bool, hitTime float64, contactNormal Vector2f) {
+	// Solve |start + velocity * t - center| = radius for t
+	offset := start.Sub(center)
+
+	a := velocity.Dot(velocity)
+	b := 2 * offset.Dot(velocity)
+	c := offset.Dot(offset) - radius*radius
+
+	discriminant := b*b - 4*a*c
+	if a == 0 || discriminant < 0 {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	// The first time the ray touches the circle
+	hitTime = (-b - math.Sqrt(discriminant)) / (2 * a)
+
+	if hitTime < -collisionEpsilon || hitTime >= 1 {
+		return false, 1.0, NewVector2f(0, 0)
+	}
+
+	// The normal points from the center to the contact point
+	contactNormal = NewVector2f(
+		(offset.X+velocity.X*hitTime)/radius,
+		(offset.Y+velocity.Y*hitTime)/radius,
+	)
+
+	return true, hitTime, contactNormal
+}
+
+// Returns the outline of the shape as a convex polygon
+// The rounded corners are made out of straight edges
+func ShapePolygon(shape Shape) []Vector2f {
+	core, radius := shape.Rounded()
+
+	if radius == 0 {
+		return NewCollider(core).Polygon()
+	}
+
+	// Number of edges in each rounded corner
+	const segments = 4
+
+	points := make([]Vector2f, 0)
+
+	corners := []Vector2f{
+		NewVector2f(core.Position.X+core.Size.X, core.Position.Y+core.Size.Y),
+		NewVector2f(core.Position.X, core.Position.Y+core.Size.Y),
+		core.Position,
+		NewVector2f(core.Position.X+core.Size.X, core.Position.Y),
+	}
+
+	// Go around the corners, a quarter of a circle each
+	for i, corner := range corners {
+		for segment := range segments + 1 {
+			angle := (float64(i) + float64(segment)/segments) * math.Pi / 2
+			points = append(points, NewVector2f(corner.X+math.Cos(angle)*radius, corner.Y+math.Sin(angle)*radius))
+		}
+	}
+
+	return removeDuplicatePoints(points)
+}
+
+// Returns the polygon grown by the shape, centered on the shape
+// Sweeping the shape against the polygon is the same as casting a ray from its center against the grown polygon
+func ExpandPolygonByShape(points []Vector2f, shape Shape) []Vector2f {
+	center := ShapeCenter(shape)
+	outline := ShapePolygon(shape)
+
+	expanded := make([]Vector2f, 0, len(points)*len(outline))
+
+	for _, point := range points {
+		for _, edge := range outline {
+			expanded = append(expanded, point.Add(edge).Sub(center))
+		}
+	}
+
+	return convexHull(expanded)
+}
+
+// Collision shape types
+const (
+	ShapeBox int = iota
+	ShapeCircle
+	ShapeCapsule
+	ShapeRoundedBox
+)
+
+// Collision shape of an entity, fitted inside the body of the entity
+// Entities without this component collide as boxes
+type BodyShape struct {
+	Type int
+
+	// Radius of the corners of a rounded box
+	Radius float64
+}
+
+func NewBodyShape(shapeType int) BodyShape {
+	return BodyShape{shapeType, 2}
+}
+
+// Returns the shape fitted inside the body
+func (bodyShape BodyShape) Fit(body Body) Shape {
+	center := body.Center()
+
+	switch bodyShape.Type {
+	case ShapeCircle:
+		return NewCircle(center, min(body.Size.X, body.Size.Y)/2)
+	case ShapeCapsule:
+		// The capsule is rounded on its shorter sides
+		radius := min(body.Size.X, body.Size.Y) / 2
+
+		start := NewVector2f(center.X, body.Position.Y+radius)
+		end := NewVector2f(center.X, body.Position.Y+body.Size.Y-radius)
+
+		if body.Size.Y < body.Size.X {
+			start = NewVector2f(body.Position.X+radius, center.Y)
+			end = NewVector2f(body.Position.X+body.Size.X-radius, center.Y)
+		}
+
+		// The segment is always horizontal or vertical
+		capsule, _ := NewCapsule(start, end, radius)
+
+		return capsule
+	case ShapeRoundedBox:
+		return NewRoundedBox(body, bodyShape.Radius)
+	}
+
+	return NewAABB(body)
+}
+
+// Returns true if the shape moving with the velocity hits the collider during this step
+func (collider Collider) VsShape(shape Shape, velocity Vector2f) (collision bool, hitTime float64, contactNormal Vector2f) {
+	// Rectangles are exact
+	if collider.Slope == SlopeNone {
+		return Sweep(shape, velocity, NewAABB(collider.Body))
+	}
+
+	// Slopes are polygons, so the rounded corners of the shape are made out of straight edges
+	expanded := ExpandPolygonByShape(collider.Polygon(), shape)
+
+	return RayVsPolygon(expanded, ShapeCenter(shape), velocity)
+}
+
+// Resolves collisions between a rounded shape and the colliders
+// Shapes which were on the ground are snapped to it, only if the gravity pulls them down
+// The velocity slides along the surfaces it hits, so round shapes roll off corners
+func (bodyA Body) CollidesWithShape(shape Shape, colliders []Collider, force *Force) {
+	// Slice to store data about shape collisions
+	type CollisionData struct {
+		// Position of the collider in the colliders slice
+		Index int
+
+		// Time taken to hit the collider
+		HitTime float64
+	}
+
+	collisionData := make([]CollisionData, 0)
+
+	// Whether the body was standing on the ground in the previous step
+	// Only bodies pulled down are snapped to the ground
+	grounded := force.Collisions.Down && gravityTurns(force.Gravity) == 0
+
+	// Reset the collisions
+	force.Collisions.Reset()
+
+	// Slopes are resolved first, like for the rectangles, so the shape does not lose speed walking uphill
+	bounds := Bounds(shape)
+	boxes := bounds.CollidesWithSlopes(colliders, force)
+
+	for i, collider := range boxes {
+		// Carry out a broad phase to stop handling
+		if !bounds.BroadPhase(collider.Body, force.Velocity) {
+			continue
+		}
+
+		collision, hitTime, _ := collider.VsShape(shape, force.Velocity)
+
+		if collision {
+			collisionData = append(collisionData, CollisionData{i, hitTime})
+		}
+	}
+
+	// Sort the colliders by which collision happened first
+	sort.SliceStable(collisionData, func(a, b int) bool {
+		return collisionData[a].HitTime < collisionData[b].HitTime
+	})
+
+	// Resolve the collisions
+	for _, data := range collisionData {
+		collider := boxes[data.Index]
+
+		// The velocity may have changed from the previous collisions, so check again
+		collision, hitTime, contactNormal := collider.VsShape(shape, force.Velocity)
+
+		if !collision {
+			continue
+		}
+
+		// Skip one-way platforms which the body is not landing on
+		if !collider.Blocks(bodyA, force, contactNormal) {
+			continue
+		}
+
+		// Walking off a slope onto the top of a tile is not a wall hit
+		feet := bounds.Position.Y + bounds.Size.Y + force.Velocity.Y
+		if force.Collisions.Slope && contactNormal.X != 0 && collider.Body.Position.Y >= feet-collisionEpsilon {
+			continue
+		}
+
+		// Only the part of the velocity going into the surface is removed
+		speed := force.Velocity.Dot(contactNormal)
+
+		// The shape is already moving away from the surface
+		if speed >= 0 {
+			continue
+		}
+
+		force.Collisions.Contacts = append(force.Collisions.Contacts, NewContact(Bounds(shape), collider, force.Velocity, hitTime, contactNormal))
+
+		force.Velocity.X -= float64(contactNormal.X * speed * (1 - hitTime))
+		force.Velocity.Y -= float64(contactNormal.Y * speed * (1 - hitTime))
+
+		// Update the collision direction
+		force.Collisions.UpdateMaterial(collider.Material, contactNormal, force.Gravity)
+		force.Collisions.Update(contactNormal)
+
+		// Standing on a platform which can be dropped through
+		if collider.OneWay {
+			force.Collisions.Platform = true
+		}
+	}
+
+	// Stay on the ground when walking down slopes
+	if grounded {
+		bodyA.SnapToGround(colliders, force)
+	}
+
+	// Count down the drop through timer
+	force.DropThrough = max(0, force.DropThrough-1)
+}
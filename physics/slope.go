@@ -0,0 +1,266 @@
+package physics
+
+import (
+	"math"
+)
+
+// Shape of a sloped tile
+type Slope int
+
+const (
+	// Not a slope (a full rectangle)
+	SlopeNone Slope = iota
+
+	// 45° floors rising towards the right and towards the left
+	SlopeFloorRight45
+	SlopeFloorLeft45
+
+	// 22.5° floors take up two tiles, a low half and a high half
+	SlopeFloorRight22Low
+	SlopeFloorRight22High
+	SlopeFloorLeft22Low
+	SlopeFloorLeft22High
+
+	// Ceilings are the floors flipped upside down
+	SlopeCeilingRight45
+	SlopeCeilingLeft45
+	SlopeCeilingRight22Low
+	SlopeCeilingRight22High
+	SlopeCeilingLeft22Low
+	SlopeCeilingLeft22High
+)
+
+// Returns the height of the surface at the left and right edges of the tile
+// Heights range from 0.0 (empty) to 1.0 (the full tile)
+// Floor heights are measured from the bottom of the tile, and ceiling heights from the top
+func (slope Slope) Heights() (left, right float64) {
+	switch slope {
+	case SlopeFloorRight45, SlopeCeilingRight45:
+		return 0, 1
+	case SlopeFloorLeft45, SlopeCeilingLeft45:
+		return 1, 0
+	case SlopeFloorRight22Low, SlopeCeilingRight22Low:
+		return 0, 0.5
+	case SlopeFloorRight22High, SlopeCeilingRight22High:
+		return 0.5, 1
+	case SlopeFloorLeft22Low, SlopeCeilingLeft22Low:
+		return 0.5, 0
+	case SlopeFloorLeft22High, SlopeCeilingLeft22High:
+		return 1, 0.5
+	}
+
+	return 1, 1
+}
+
+// Returns true if the slope is solid above its surface
+func (slope Slope) Ceiling() bool {
+	return slope >= SlopeCeilingRight45
+}
+
+// Returns the height of the surface at x
+// Outside of the tile, the height of the closest edge is used, unless the surface is extended
+func (collider Collider) surfaceHeight(x float64, extend bool) float64 {
+	left, right := collider.Slope.Heights()
+
+	// How far along the tile x is (ranges from 0.0 to 1.0 inside the tile)
+	t := (x - collider.Body.Position.X) / collider.Body.Size.X
+	if !extend {
+		t = max(0, min(1, t))
+	}
+
+	return left + float64((right-left)*t)
+}
+
+// Converts the highest height of the surface underneath the body into a y co-ordinate
+func (collider Collider) surfaceY(body Body, extend bool) float64 {
+	// The surface is a straight line, so the highest point is on one of the edges of the body
+	height := max(
+		collider.surfaceHeight(body.Position.X, extend),
+		collider.surfaceHeight(body.Position.X+body.Size.X, extend),
+	)
+
+	if collider.Slope.Ceiling() {
+		return collider.Body.Position.Y + height*collider.Body.Size.Y
+	}
+
+	return collider.Body.Position.Y + collider.Body.Size.Y - height*collider.Body.Size.Y
+}
+
+// Returns the y co-ordinate of the part of the surface which the body touches
+// For floors, this is the highest point of the surface underneath the body
+// For ceilings, this is the lowest point of the surface above the body
+func (collider Collider) SurfaceAt(body Body) float64 {
+	return collider.surfaceY(body, false)
+}
+
+// Returns the y co-ordinate of the surface which the body had to be beyond in the previous step to land on the slope
+// The surface is also extended past the edges of the tile, so that bodies on the neighbouring slopes can walk onto it
+func (collider Collider) previousSurfaceAt(body Body) float64 {
+	surface := collider.surfaceY(body, false)
+	extended := collider.surfaceY(body, true)
+
+	// Use whichever surface is further away from the solid part of the tile
+	if collider.Slope.Ceiling() {
+		return min(surface, extended)
+	}
+
+	return max(surface, extended)
+}
+
+// Resolves collisions between the body and sloped colliders
+// Returns the colliders which still have to be resolved as rectangles
+func (bodyA Body) CollidesWithSlopes(colliders []Collider, force *Force) []Collider {
+	boxes := make([]Collider, 0, len(colliders))
+
+	// Where the body would end up if nothing stopped it
+	moved := bodyA
+	moved.Position.X += force.Velocity.X
+	moved.Position.Y += force.Velocity.Y
+
+	for _, collider := range colliders {
+		// Rectangles are resolved later
+		if collider.Slope == SlopeNone {
+			boxes = append(boxes, collider)
+			continue
+		}
+
+		// The body can only hit the slope if it ends up inside the tile
+		if !moved.CollidesWithStaticBody(collider.Body) {
+			continue
+		}
+
+		// The surface where the body ends up, and where the body was in the previous step
+		surface := collider.SurfaceAt(moved)
+		previous := collider.previousSurfaceAt(bodyA)
+
+		if collider.Slope.Ceiling() {
+			head := bodyA.Position.Y
+
+			// The body stays below the surface
+			if moved.Position.Y >= surface {
+				continue
+			}
+
+			// If the body was not below the surface, it hit the side or the top of the tile
+			if head < previous-collisionEpsilon {
+				boxes = append(boxes, collider.box())
+				continue
+			}
+
+			// Move the head onto the surface
+			contact := surfaceContact(bodyA, collider, force.Velocity, surface, NewVector2f(0, 1))
+			contact.Penetration = surface - moved.Position.Y
+			force.Collisions.Contacts = append(force.Collisions.Contacts, contact)
+
+			force.Velocity.Y = max(force.Velocity.Y, surface-head)
+
+			force.Collisions.UpdateMaterial(collider.Material, NewVector2f(0, 1), force.Gravity)
+			force.Collisions.Up = true
+		} else {
+			feet := bodyA.Position.Y + bodyA.Size.Y
+
+			// The body stays above the surface
+			if moved.Position.Y+moved.Size.Y <= surface {
+				continue
+			}
+
+			// If the body was not above the surface, it hit the side or the bottom of the tile
+			if feet > previous+collisionEpsilon {
+				boxes = append(boxes, collider.box())
+				continue
+			}
+
+			// Move the feet onto the surface
+			// Only the vertical velocity changes, so the body does not lose speed walking uphill
+			contact := surfaceContact(bodyA, collider, force.Velocity, surface, NewVector2f(0, -1))
+			contact.Penetration = moved.Position.Y + moved.Size.Y - surface
+			force.Collisions.Contacts = append(force.Collisions.Contacts, contact)
+
+			force.Velocity.Y = min(force.Velocity.Y, surface-feet)
+
+			force.Collisions.UpdateMaterial(collider.Material, NewVector2f(0, -1), force.Gravity)
+			force.Collisions.Down = true
+			force.Collisions.Slope = true
+		}
+	}
+
+	return boxes
+}
+
+// Returns how far down the ground can be snapped to
+func snapDistance(velocity Vector2f) float64 {
+	// The steepest slopes are 45°, so the ground can't fall away faster than the body moves sideways
+	// The body can also fall one step behind when the edge of the tile next to the slope held it up
+	return 2*math.Abs(velocity.X) + 1
+}
+
+// Keeps a body which was on the ground on the ground when walking down slopes and steps
+// The ground is always below the body, so the bodies in gravity zones which pull another way are never snapped
+// Slopes are only solid from one side, so snapping them onto the walls and ceilings would not keep the bodies on them
+func (bodyA Body) SnapToGround(colliders []Collider, force *Force) {
+	// The body is jumping or is already on the ground
+	if force.Velocity.Y < 0 || force.Collisions.Down {
+		return
+	}
+
+	snap := snapDistance(force.Velocity)
+
+	// Where the body ends up
+	moved := bodyA
+	moved.Position.X += force.Velocity.X
+	moved.Position.Y += force.Velocity.Y
+
+	feet := moved.Position.Y + moved.Size.Y
+
+	// Look for the ground underneath the feet
+	probe := NewBody(NewVector2f(moved.Position.X, feet), NewVector2f(moved.Size.X, snap))
+
+	ground := math.Inf(1)
+	var groundCollider Collider
+
+	for _, collider := range colliders {
+		if !probe.CollidesWithStaticBody(collider.Body) {
+			continue
+		}
+
+		// Ceilings can't be stood on
+		if collider.Slope.Ceiling() {
+			continue
+		}
+
+		// Skip one-way platforms which the body can't land on
+		if !collider.Blocks(moved, force, NewVector2f(0, -1)) {
+			continue
+		}
+
+		surface := collider.Body.Position.Y
+		if collider.Slope != SlopeNone {
+			surface = collider.SurfaceAt(moved)
+		}
+
+		// The ground has to be underneath the feet
+		if surface < feet-collisionEpsilon {
+			continue
+		}
+
+		if surface < ground {
+			ground = surface
+			groundCollider = collider
+		}
+	}
+
+	// No ground close enough to snap to
+	if ground-feet > snap {
+		return
+	}
+
+	// Move the feet onto the ground
+	force.Collisions.Contacts = append(force.Collisions.Contacts, surfaceContact(bodyA, groundCollider, force.Velocity, ground, NewVector2f(0, -1)))
+
+	force.Velocity.Y += ground - feet
+
+	force.Collisions.Down = true
+	force.Collisions.Slope = groundCollider.Slope != SlopeNone
+	force.Collisions.Platform = groundCollider.OneWay
+	force.Collisions.Material = groundCollider.Material
+}
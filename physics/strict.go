@@ -0,0 +1,28 @@
+package physics
+
+import (
+	"math"
+)
+
+// Floating point helpers which give the same result on every platform
+// Go may fuse a multiplication and an addition into one instruction on some platforms, which rounds differently
+// An explicit conversion to float64 rounds the result, and stops the operations from being fused
+
+// Returns the length of the vector (x, y)
+// Unlike math.Hypot, this only uses operations which are exactly rounded on every platform
+func hypot(x, y float64) float64 {
+	return math.Sqrt(float64(x*x) + float64(y*y))
+}
+
+// Snaps the body onto a grid, so tiny differences between runs don't grow over time
+// The unit should be a power of two, so the snapped values are exact
+func (body *Body) Quantize(unit float64) {
+	body.Position.X = Round(body.Position.X, unit)
+	body.Position.Y = Round(body.Position.Y, unit)
+}
+
+// Snaps the momentum onto a grid, so tiny differences between runs don't grow over time
+func (force *Force) Quantize(unit float64) {
+	force.Acceleration.X = Round(force.Acceleration.X, unit)
+	force.Acceleration.Y = Round(force.Acceleration.Y, unit)
+}
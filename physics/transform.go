@@ -0,0 +1,90 @@
+package physics
+
+import (
+	"math"
+)
+
+// 2D affine transform
+// A point (x, y) is moved to (A*x + C*y + E, B*x + D*y + F)
+type Transform struct {
+	A, B, C, D, E, F float64
+}
+
+// Creates a transform which does not change anything
+func NewTransform() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// Creates a transform which moves points by the offset
+func NewTranslation(offset Vector2f) Transform {
+	return Transform{A: 1, D: 1, E: offset.X, F: offset.Y}
+}
+
+// Creates a transform which scales points from the origin
+func NewScaling(scale Vector2f) Transform {
+	return Transform{A: scale.X, D: scale.Y}
+}
+
+// Creates a transform which rotates points around the origin by the angle in radians
+// The rotation is clockwise on the screen, where y points down
+func NewRotation(angle float64) Transform {
+	sin, cos := math.Sincos(angle)
+
+	return Transform{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Returns the transform which rotates around the pivot by the angle in radians
+func NewRotationAround(pivot Vector2f, angle float64) Transform {
+	return NewTranslation(pivot).Compose(NewRotation(angle)).Compose(NewTranslation(pivot.Neg()))
+}
+
+// Returns the transform which applies the other transform first, and then this transform
+func (transform Transform) Compose(other Transform) Transform {
+	return Transform{
+		A: transform.A*other.A + transform.C*other.B,
+		B: transform.B*other.A + transform.D*other.B,
+		C: transform.A*other.C + transform.C*other.D,
+		D: transform.B*other.C + transform.D*other.D,
+		E: transform.A*other.E + transform.C*other.F + transform.E,
+		F: transform.B*other.E + transform.D*other.F + transform.F,
+	}
+}
+
+// Returns the transform which undoes this transform
+// Returns false if the transform squashes points onto a line, so it can't be undone
+func (transform Transform) Invert() (Transform, bool) {
+	determinant := transform.A*transform.D - transform.B*transform.C
+
+	if determinant == 0 {
+		return Transform{}, false
+	}
+
+	inverse := Transform{
+		A: transform.D / determinant,
+		B: -transform.B / determinant,
+		C: -transform.C / determinant,
+		D: transform.A / determinant,
+	}
+
+	// Undo the translation after undoing the rest
+	inverse.E = -(inverse.A*transform.E + inverse.C*transform.F)
+	inverse.F = -(inverse.B*transform.E + inverse.D*transform.F)
+
+	return inverse, true
+}
+
+// Returns the transformed point
+func (transform Transform) Apply(point Vector2f) Vector2f {
+	return NewVector2f(
+		transform.A*point.X+transform.C*point.Y+transform.E,
+		transform.B*point.X+transform.D*point.Y+transform.F,
+	)
+}
+
+// Returns the transformed direction, which is not moved by the translation
+func (transform Transform) ApplyVector(vector Vector2f) Vector2f {
+	return NewVector2f(
+		transform.A*vector.X+transform.C*vector.Y,
+		transform.B*vector.X+transform.D*vector.Y,
+	)
+}
@@ -0,0 +1,89 @@
+package physics
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// Number of random cases each property is checked with
+const propertyCases = 1000
+
+// Returns a random translation, rotation and scaling
+// The scale is never close to 0, so the transform can be undone
+func randomTransform(random *rand.Rand) Transform {
+	translation := NewTranslation(randomVector(random, 100))
+	rotation := NewRotation(random.Float64() * 2 * math.Pi)
+	scaling := NewScaling(NewVector2f(0.5+random.Float64()*1.5, 0.5+random.Float64()*1.5))
+
+	return translation.Compose(rotation).Compose(scaling)
+}
+
+// Returns a random vector with each component between -size and size
+func randomVector(random *rand.Rand, size float64) Vector2f {
+	return NewVector2f((random.Float64()*2-1)*size, (random.Float64()*2-1)*size)
+}
+
+func closeTransforms(a, b Transform, tolerance float64) bool {
+	return math.Abs(a.A-b.A) < tolerance && math.Abs(a.B-b.B) < tolerance &&
+		math.Abs(a.C-b.C) < tolerance && math.Abs(a.D-b.D) < tolerance &&
+		math.Abs(a.E-b.E) < tolerance && math.Abs(a.F-b.F) < tolerance
+}
+
+func TestComposeAssociative(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		a, b, c := randomTransform(random), randomTransform(random), randomTransform(random)
+
+		left := a.Compose(b).Compose(c)
+		right := a.Compose(b.Compose(c))
+
+		if !closeTransforms(left, right, 1e-9) {
+			t.Fatalf("(a b) c = %+v, a (b c) = %+v", left, right)
+		}
+	}
+}
+
+func TestComposeApply(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		a, b := randomTransform(random), randomTransform(random)
+		point := randomVector(random, 100)
+
+		composed := a.Compose(b).Apply(point)
+		applied := a.Apply(b.Apply(point))
+
+		if composed.Distance(applied) > 1e-9 {
+			t.Fatalf("composed transform moved %v to %v, want %v", point, composed, applied)
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		transform := randomTransform(random)
+
+		inverse, ok := transform.Invert()
+		if !ok {
+			t.Fatalf("transform %+v could not be undone", transform)
+		}
+
+		// Undoing in either order gives back the identity
+		if identity := inverse.Compose(transform); !closeTransforms(identity, NewTransform(), 1e-9) {
+			t.Fatalf("inverse after transform = %+v, want the identity", identity)
+		}
+
+		if identity := transform.Compose(inverse); !closeTransforms(identity, NewTransform(), 1e-9) {
+			t.Fatalf("transform after inverse = %+v, want the identity", identity)
+		}
+	}
+
+	// Squashing everything onto a line can't be undone
+	if _, ok := NewScaling(NewVector2f(1, 0)).Invert(); ok {
+		t.Errorf("squashing transform was undone")
+	}
+}
@@ -18,14 +18,79 @@ func (vector Vector2f) String() string {
 	return fmt.Sprintf("%f\t%f", vector.X, vector.Y)
 }
 
-// Utilizes pythagoras to find the distance between two points
-func (vectorA Vector2f) Distance(vectorB Vector2f) float64 {
-	return math.Hypot(vectorA.X+vectorB.X, vectorA.Y+vectorB.Y)
+// Returns the sum of the vectors
+func (vectorA Vector2f) Add(vectorB Vector2f) Vector2f {
+	return Vector2f{vectorA.X + vectorB.X, vectorA.Y + vectorB.Y}
+}
+
+// Returns vector B subtracted from vector A
+func (vectorA Vector2f) Sub(vectorB Vector2f) Vector2f {
+	return Vector2f{vectorA.X - vectorB.X, vectorA.Y - vectorB.Y}
+}
+
+// Returns the vector multiplied by the scalar
+func (vector Vector2f) Scale(scalar float64) Vector2f {
+	return Vector2f{vector.X * scalar, vector.Y * scalar}
+}
+
+// Returns the vectors multiplied component by component
+func (vectorA Vector2f) Mul(vectorB Vector2f) Vector2f {
+	return Vector2f{vectorA.X * vectorB.X, vectorA.Y * vectorB.Y}
+}
+
+// Returns the vector pointing the other way
+func (vector Vector2f) Neg() Vector2f {
+	return Vector2f{-vector.X, -vector.Y}
+}
+
+// Dot product of the vectors
+func (vectorA Vector2f) Dot(vectorB Vector2f) float64 {
+	return float64(vectorA.X*vectorB.X) + float64(vectorA.Y*vectorB.Y)
+}
+
+// Cross product of the vectors
+// Positive if vector B is clockwise from vector A on the screen, where y points down
+func (vectorA Vector2f) Cross(vectorB Vector2f) float64 {
+	return float64(vectorA.X*vectorB.Y) - float64(vectorA.Y*vectorB.X)
 }
 
 // Magnitude of the vector
 func (vector Vector2f) Magnitude() float64 {
-	return math.Hypot(vector.X, vector.Y)
+	return hypot(vector.X, vector.Y)
+}
+
+// Returns the vector with a length of 1
+// The zero vector stays the zero vector
+func (vector Vector2f) Normalize() Vector2f {
+	magnitude := vector.Magnitude()
+
+	if magnitude == 0 {
+		return vector
+	}
+
+	return Vector2f{vector.X / magnitude, vector.Y / magnitude}
+}
+
+// Utilizes pythagoras to find the distance between two points
+func (vectorA Vector2f) Distance(vectorB Vector2f) float64 {
+	return hypot(vectorA.X-vectorB.X, vectorA.Y-vectorB.Y)
+}
+
+// Returns the point a fraction of the way from vector A to vector B (t ranges from 0.0 to 1.0)
+func (vectorA Vector2f) Lerp(vectorB Vector2f, t float64) Vector2f {
+	return Vector2f{vectorA.X + float64((vectorB.X-vectorA.X)*t), vectorA.Y + float64((vectorB.Y-vectorA.Y)*t)}
+}
+
+// Returns the vector rotated clockwise on the screen by the angle in radians
+func (vector Vector2f) Rotate(angle float64) Vector2f {
+	sin, cos := math.Sincos(angle)
+
+	return Vector2f{float64(vector.X*cos) - float64(vector.Y*sin), float64(vector.X*sin) + float64(vector.Y*cos)}
+}
+
+// Angle of the vector from the x-axis in radians, clockwise on the screen
+func (vector Vector2f) Angle() float64 {
+	return math.Atan2(vector.Y, vector.X)
 }
 
 // Slope of the vector
@@ -0,0 +1,55 @@
+package physics
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		vector := randomVector(random, 1000)
+
+		if vector.X == 0 && vector.Y == 0 {
+			continue
+		}
+
+		normalized := vector.Normalize()
+
+		if math.Abs(normalized.Magnitude()-1) > 1e-12 {
+			t.Fatalf("normalized %v has length %v, want 1", vector, normalized.Magnitude())
+		}
+
+		// The direction stays the same
+		if math.Abs(normalized.Cross(vector)) > 1e-9 || normalized.Dot(vector) <= 0 {
+			t.Fatalf("normalized %v points along %v", vector, normalized)
+		}
+	}
+
+	// The zero vector stays the zero vector
+	if zero := NewVector2f(0, 0).Normalize(); zero.X != 0 || zero.Y != 0 {
+		t.Errorf("normalized zero vector = %v, want the zero vector", zero)
+	}
+}
+
+func TestRotatePreservesMagnitude(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for range propertyCases {
+		vector := randomVector(random, 1000)
+		angle := (random.Float64()*2 - 1) * 4 * math.Pi
+
+		rotated := vector.Rotate(angle)
+
+		if math.Abs(rotated.Magnitude()-vector.Magnitude()) > 1e-9 {
+			t.Fatalf("%v rotated by %v has length %v, want %v", vector, angle, rotated.Magnitude(), vector.Magnitude())
+		}
+
+		// Rotating back gives the same vector
+		if back := rotated.Rotate(-angle); back.Distance(vector) > 1e-9 {
+			t.Fatalf("%v rotated by %v and back = %v", vector, angle, back)
+		}
+	}
+}
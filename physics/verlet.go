@@ -0,0 +1,281 @@
+package physics
+
+import (
+	"math"
+)
+
+const (
+	// Constraints between the particles of a verlet body
+	ConstraintDistance int = iota
+	ConstraintPin
+	ConstraintAngle
+)
+
+// Point moved by verlet integration
+// The velocity of the particle is the difference between its position and its previous position
+type Particle struct {
+	Position, Previous Vector2f
+
+	// Pinned particles are only moved by their pins
+	Pinned bool
+}
+
+// Rule the particles of a verlet body are moved to follow
+type Constraint struct {
+	Kind int
+
+	// Indices of the particles
+	// Distance constraints join A and B, pins hold A, and angle constraints bend at B between A and C
+	A, B, C int
+
+	// Rest length of a distance constraint, or the shortest distance between A and C of an angle constraint
+	Length float64
+
+	// Where a pin holds its particle
+	Point Vector2f
+
+	// Fraction of the error corrected each iteration (ranges from 0.0 to 1.0)
+	Stiffness float64
+}
+
+// Particles joined by constraints, like ropes and chains
+type Verlet struct {
+	Particles   []Particle
+	Constraints []Constraint
+
+	// Number of times the constraints are solved each step
+	// More iterations give stiffer bodies
+	Iterations int
+
+	// Fraction of the velocity kept each step (ranges from 0.0 to 1.0)
+	Damping float64
+
+	// Size of the particles against the tiles
+	Radius float64
+}
+
+// Creates a new verlet body without particles
+func NewVerlet(iterations int) Verlet {
+	verlet := Verlet{}
+
+	verlet.Particles = make([]Particle, 0)
+	verlet.Constraints = make([]Constraint, 0)
+	verlet.Iterations = iterations
+	verlet.Damping = 0.99
+	verlet.Radius = 2
+
+	return verlet
+}
+
+// Creates a rope of segments between the start and the end, which hangs from the start
+func NewRope(start, end Vector2f, segments int) Verlet {
+	verlet := NewVerlet(8)
+
+	for i := range segments + 1 {
+		verlet.AddParticle(start.Lerp(end, float64(i)/float64(segments)))
+
+		if i > 0 {
+			verlet.AddDistance(i-1, i)
+		}
+	}
+
+	verlet.AddPin(0, start)
+
+	return verlet
+}
+
+// Adds a particle at rest, and returns its index
+func (verlet *Verlet) AddParticle(position Vector2f) int {
+	verlet.Particles = append(verlet.Particles, Particle{Position: position, Previous: position})
+
+	return len(verlet.Particles) - 1
+}
+
+// Keeps the particles at their current distance
+func (verlet *Verlet) AddDistance(a, b int) {
+	verlet.Constraints = append(verlet.Constraints, Constraint{
+		Kind:      ConstraintDistance,
+		A:         a,
+		B:         b,
+		Length:    verlet.Particles[a].Position.Distance(verlet.Particles[b].Position),
+		Stiffness: 1,
+	})
+}
+
+// Holds the particle at the point
+func (verlet *Verlet) AddPin(a int, point Vector2f) {
+	verlet.Particles[a].Pinned = true
+
+	verlet.Constraints = append(verlet.Constraints, Constraint{
+		Kind:      ConstraintPin,
+		A:         a,
+		Point:     point,
+		Stiffness: 1,
+	})
+}
+
+// Moves the pins of the particle to the point
+func (verlet *Verlet) MovePin(a int, point Vector2f) {
+	for i, constraint := range verlet.Constraints {
+		if constraint.Kind == ConstraintPin && constraint.A == a {
+			verlet.Constraints[i].Point = point
+		}
+	}
+}
+
+// Stops the particles from bending at b by less than the angle, in radians
+func (verlet *Verlet) AddAngle(a, b, c int, minAngle float64) {
+	lengthA := verlet.Particles[a].Position.Distance(verlet.Particles[b].Position)
+	lengthC := verlet.Particles[c].Position.Distance(verlet.Particles[b].Position)
+
+	// Law of cosines
+	length := math.Sqrt(lengthA*lengthA + lengthC*lengthC - 2*lengthA*lengthC*math.Cos(minAngle))
+
+	verlet.Constraints = append(verlet.Constraints, Constraint{
+		Kind:      ConstraintAngle,
+		A:         a,
+		B:         b,
+		C:         c,
+		Length:    length,
+		Stiffness: 0.5,
+	})
+}
+
+// Stops every three particles in a row from bending by less than the angle, so ropes hang like chains
+func (verlet *Verlet) Stiffen(minAngle float64) {
+	for i := 1; i+1 < len(verlet.Particles); i++ {
+		verlet.AddAngle(i-1, i, i+1, minAngle)
+	}
+}
+
+// Returns the positions of the particles
+func (verlet Verlet) Points() []Vector2f {
+	points := make([]Vector2f, len(verlet.Particles))
+
+	for i, particle := range verlet.Particles {
+		points[i] = particle.Position
+	}
+
+	return points
+}
+
+// Returns the area the particles cover
+func (verlet Verlet) Bounds() Body {
+	if len(verlet.Particles) == 0 {
+		return NewBody(NewVector2f(0, 0), NewVector2f(0, 0))
+	}
+
+	bounds := NewBody(verlet.Particles[0].Position, NewVector2f(0, 0))
+
+	for _, particle := range verlet.Particles {
+		bounds = bounds.Union(NewBody(particle.Position, NewVector2f(0, 0)))
+	}
+
+	return bounds.Expand(NewVector2f(verlet.Radius, verlet.Radius))
+}
+
+// Moves the particles by their velocity and the gravity, then solves the constraints and the collisions with the colliders
+func (verlet *Verlet) Step(gravity Vector2f, colliders []Collider) {
+	for i := range verlet.Particles {
+		particle := &verlet.Particles[i]
+
+		if particle.Pinned {
+			continue
+		}
+
+		velocity := particle.Position.Sub(particle.Previous).Scale(verlet.Damping)
+
+		particle.Previous = particle.Position
+		particle.Position = particle.Position.Add(velocity).Add(gravity)
+	}
+
+	for range verlet.Iterations {
+		for _, constraint := range verlet.Constraints {
+			verlet.solve(constraint)
+		}
+
+		for i := range verlet.Particles {
+			if !verlet.Particles[i].Pinned {
+				verlet.collide(&verlet.Particles[i], colliders)
+			}
+		}
+	}
+}
+
+// Moves the particles of the constraint towards following it
+func (verlet *Verlet) solve(constraint Constraint) {
+	switch constraint.Kind {
+	case ConstraintPin:
+		verlet.Particles[constraint.A].Position = constraint.Point
+	case ConstraintDistance:
+		verlet.separate(constraint.A, constraint.B, constraint.Length, constraint.Stiffness, false)
+	case ConstraintAngle:
+		// The ends of the bend are only pushed apart
+		verlet.separate(constraint.A, constraint.C, constraint.Length, constraint.Stiffness, true)
+	}
+}
+
+// Moves the two particles towards the length apart
+// Pinned particles don't move, so the other particle is moved the whole way
+func (verlet *Verlet) separate(a, b int, length, stiffness float64, pushOnly bool) {
+	particleA := &verlet.Particles[a]
+	particleB := &verlet.Particles[b]
+
+	delta := particleB.Position.Sub(particleA.Position)
+	distance := delta.Magnitude()
+
+	if distance == 0 || (pushOnly && distance >= length) {
+		return
+	}
+
+	weightA, weightB := 1.0, 1.0
+	if particleA.Pinned {
+		weightA = 0
+	}
+	if particleB.Pinned {
+		weightB = 0
+	}
+
+	total := weightA + weightB
+	if total == 0 {
+		return
+	}
+
+	correction := delta.Scale((distance - length) / distance * stiffness)
+
+	particleA.Position = particleA.Position.Add(correction.Scale(weightA / total))
+	particleB.Position = particleB.Position.Sub(correction.Scale(weightB / total))
+}
+
+// Pushes the particle out of the solid colliders, along the shortest way out
+func (verlet *Verlet) collide(particle *Particle, colliders []Collider) {
+	for _, collider := range colliders {
+		// Ropes hang through one-way platforms
+		if collider.OneWay {
+			continue
+		}
+
+		box := NewBody(particle.Position, NewVector2f(0, 0)).Expand(NewVector2f(verlet.Radius, verlet.Radius))
+
+		overlap, ok := box.Intersection(collider.Body)
+		if !ok {
+			continue
+		}
+
+		center := collider.Body.Center()
+
+		if overlap.Size.X < overlap.Size.Y {
+			if particle.Position.X < center.X {
+				particle.Position.X -= overlap.Size.X
+			} else {
+				particle.Position.X += overlap.Size.X
+			}
+		} else {
+			if particle.Position.Y < center.Y {
+				particle.Position.Y -= overlap.Size.Y
+			} else {
+				particle.Position.Y += overlap.Size.Y
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package physics
+
+// Sides of a body
+const (
+	SideLeft  int = -1
+	SideNone  int = 0
+	SideRight int = 1
+)
+
+// Returns true if there is a wall right next to the side of the body
+func (body Body) TouchesWall(colliders []Collider, side int) bool {
+	// Probe one pixel beside the body
+	probe := NewBody(NewVector2f(body.Position.X+body.Size.X, body.Position.Y), NewVector2f(1, body.Size.Y))
+	if side == SideLeft {
+		probe.Position.X = body.Position.X - 1
+	}
+
+	for _, collider := range colliders {
+		// One-way platforms and slopes are not walls
+		if collider.OneWay || collider.Slope != SlopeNone {
+			continue
+		}
+
+		if probe.CollidesWithStaticBody(collider.Body) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the side of the wall next to the body, preferring the side which is held
+func (body Body) WallSide(colliders []Collider, input ControllerInput) int {
+	left := body.TouchesWall(colliders, SideLeft)
+	right := body.TouchesWall(colliders, SideRight)
+
+	switch {
+	case left && (input.Left || !right):
+		return SideLeft
+	case right:
+		return SideRight
+	}
+
+	return SideNone
+}
+
+// Returns the corner of the ledge beside the body, if the body can hang from it
+// The top of the ledge has to be within reach of the top of the body, with room above it to climb onto
+func (body Body) FindLedge(colliders []Collider, side int, reach float64) (Vector2f, bool) {
+	// The edge of the body next to the wall
+	x := body.Position.X
+	if side == SideRight {
+		x += body.Size.X
+	}
+
+	// Probe beside the head of the body
+	probe := NewBody(NewVector2f(x, body.Position.Y-reach), NewVector2f(1, reach*2))
+	if side == SideLeft {
+		probe.Position.X -= 1
+	}
+
+	found := false
+	ledge := NewVector2f(x, 0)
+
+	for _, collider := range colliders {
+		// Only solid rectangles have ledges
+		if collider.OneWay || collider.Slope != SlopeNone {
+			continue
+		}
+
+		if !probe.CollidesWithStaticBody(collider.Body) {
+			continue
+		}
+
+		// The highest wall is the ledge
+		if !found || collider.Body.Position.Y < ledge.Y {
+			ledge.Y = collider.Body.Position.Y
+			found = true
+		}
+	}
+
+	// The wall carries on above the reach of the body
+	if !found || ledge.Y < probe.Position.Y {
+		return ledge, false
+	}
+
+	// Probe above the ledge, where the body stands after climbing up
+	room := NewBody(NewVector2f(x, ledge.Y-body.Size.Y), body.Size)
+	if side == SideLeft {
+		room.Position.X -= body.Size.X
+	}
+
+	for _, collider := range colliders {
+		if collider.OneWay {
+			continue
+		}
+
+		if room.CollidesWithStaticBody(collider.Body) {
+			return ledge, false
+		}
+	}
+
+	return ledge, true
+}
@@ -0,0 +1,349 @@
+package physics
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// Collision layers
+	// Each collider is on one layer, and queries use a mask of the layers they look for
+	LayerTile uint32 = 1 << iota
+	LayerPlatform
+	LayerPlayer
+	LayerEnemy
+	LayerProjectile
+	LayerClimbable
+	LayerFluid
+	LayerGravity
+	LayerForceField
+
+	// Every layer
+	LayerAll uint32 = math.MaxUint32
+)
+
+// Result of a ray or shape cast
+type RaycastHit struct {
+	// Entity id of the collider which was hit
+	Id int
+
+	// Where the ray hit the collider
+	Point Vector2f
+
+	// Normal of the surface which was hit
+	Normal Vector2f
+
+	// How far along the ray the hit happened (ranges from 0.0 to 1.0)
+	Fraction float64
+}
+
+// The physics world stores the colliders in a grid of cells
+// so that queries only have to check the colliders near them
+type World struct {
+	Colliders []Collider
+
+	// Size of each cell of the spatial index
+	CellSize float64
+
+	// Indices of the colliders in each cell
+	cells map[[2]int][]int
+
+	// The first colliders are static, and are kept when the world is cleared
+	static      int
+	baked       bool
+	staticCells map[[2]int][]int
+
+	// Debug data the queries are recorded into
+	// Nothing is recorded if there is no debug data
+	Debug *DebugData
+
+	// The query each collider was last found by, so colliders in multiple cells are only found once
+	// Reusing the marks saves clearing a set of the found colliders for every query
+	marks []int
+	query int
+}
+
+// Creates a new empty world
+func NewWorld(cellSize float64) World {
+	world := World{}
+
+	world.Colliders = make([]Collider, 0)
+	world.CellSize = cellSize
+	world.cells = make(map[[2]int][]int)
+	world.static = 0
+	world.baked = false
+	world.staticCells = make(map[[2]int][]int)
+	world.Debug = nil
+	world.marks = make([]int, 0)
+	world.query = 0
+
+	return world
+}
+
+// Removes all the colliders, except the static colliders
+func (world *World) Clear() {
+	world.Colliders = world.Colliders[:world.static]
+	world.cells = make(map[[2]int][]int)
+}
+
+// Removes all the colliders, including the static colliders
+// The queries are still recorded into the same debug data
+func (world *World) Reset() {
+	debug := world.Debug
+
+	*world = NewWorld(world.CellSize)
+	world.Debug = debug
+}
+
+// Keeps the colliders which have been added so far when the world is cleared
+// Static colliders never move, so they only have to be added once
+// This MUST be handled right AFTER clearing the world and adding the static colliders
+func (world *World) Bake() {
+	for cell, indices := range world.cells {
+		world.staticCells[cell] = append(world.staticCells[cell], indices...)
+	}
+
+	world.cells = make(map[[2]int][]int)
+	world.static = len(world.Colliders)
+	world.baked = true
+}
+
+// Returns true if the static colliders have been baked into the world
+func (world *World) Baked() bool {
+	return world.baked
+}
+
+// Adds a collider to the world
+func (world *World) Add(collider Collider) {
+	index := len(world.Colliders)
+	world.Colliders = append(world.Colliders, collider)
+
+	// Add the collider to every cell it covers
+	minimum, maximum := world.cellRange(collider.Body)
+
+	for y := minimum[1]; y <= maximum[1]; y++ {
+		for x := minimum[0]; x <= maximum[0]; x++ {
+			cell := [2]int{x, y}
+			world.cells[cell] = append(world.cells[cell], index)
+		}
+	}
+}
+
+// Returns the first and last cells covered by the body
+func (world *World) cellRange(body Body) (minimum, maximum [2]int) {
+	minimum[0] = int(math.Floor(body.Position.X / world.CellSize))
+	minimum[1] = int(math.Floor(body.Position.Y / world.CellSize))
+
+	maximum[0] = int(math.Floor((body.Position.X + body.Size.X) / world.CellSize))
+	maximum[1] = int(math.Floor((body.Position.Y + body.Size.Y) / world.CellSize))
+
+	return minimum, maximum
+}
+
+// Returns the colliders on the masked layers in the cells covered by the area
+// The colliders are in the order they were added, and may not actually touch the area
+func (world *World) Nearby(area Body, mask uint32) []Collider {
+	nearby := make([]Collider, 0)
+
+	for _, index := range world.nearbyIndices(area, mask) {
+		nearby = append(nearby, world.Colliders[index])
+	}
+
+	return nearby
+}
+
+func (world *World) nearbyIndices(area Body, mask uint32) []int {
+	indices := make([]int, 0)
+
+	// A collider can be in multiple cells, so skip the ones which have already been found by this query
+	if len(world.marks) < len(world.Colliders) {
+		world.marks = append(world.marks, make([]int, len(world.Colliders)-len(world.marks))...)
+	}
+
+	world.query++
+
+	minimum, maximum := world.cellRange(area)
+
+	for y := minimum[1]; y <= maximum[1]; y++ {
+		for x := minimum[0]; x <= maximum[0]; x++ {
+			// The static colliders are stored apart from the moving colliders
+			cell := [2]int{x, y}
+
+			indices = world.appendCell(indices, world.staticCells[cell], mask)
+			indices = world.appendCell(indices, world.cells[cell], mask)
+		}
+	}
+
+	// Keep the order the colliders were added in
+	sort.Ints(indices)
+
+	return indices
+}
+
+// Adds the colliders of the cell on the masked layers, which have not been found yet
+func (world *World) appendCell(indices []int, cell []int, mask uint32) []int {
+	for _, index := range cell {
+		if world.marks[index] == world.query || world.Colliders[index].Layer&mask == 0 {
+			continue
+		}
+
+		world.marks[index] = world.query
+		indices = append(indices, index)
+	}
+
+	return indices
+}
+
+// Returns the area covered by a moving body
+func sweptArea(body Body, velocity Vector2f) Body {
+	return body.Union(body.Translate(velocity))
+}
+
+// Casts a ray from start along the direction and returns every collider it hits, closest first
+// Colliders which contain the start of the ray are ignored
+func (world *World) RaycastAll(start, direction Vector2f, mask uint32) []RaycastHit {
+	hits := make([]RaycastHit, 0)
+
+	// A ray is a body with no size
+	ray := NewBody(start, NewVector2f(0, 0))
+
+	for _, index := range world.nearbyIndices(sweptArea(ray, direction), mask) {
+		collider := world.Colliders[index]
+
+		var collision bool
+		var hitTime float64
+		var contactNormal Vector2f
+
+		if collider.Slope == SlopeNone {
+			collision, hitTime, contactNormal = collider.Body.VsRay(start, direction)
+
+			// The hit has to be in front of the start, and before the end of the ray
+			if hitTime < 0 || hitTime > 1 {
+				collision = false
+			}
+		} else {
+			collision, hitTime, contactNormal = RayVsPolygon(collider.Polygon(), start, direction)
+		}
+
+		if !collision {
+			continue
+		}
+
+		hit := RaycastHit{
+			Id:       collider.Id,
+			Point:    start.Add(direction.Scale(hitTime)),
+			Normal:   contactNormal,
+			Fraction: hitTime,
+		}
+
+		hits = append(hits, hit)
+	}
+
+	// Closest hits first
+	sort.SliceStable(hits, func(a, b int) bool {
+		return hits[a].Fraction < hits[b].Fraction
+	})
+
+	world.Debug.AddRaycast(start, direction, hits)
+
+	return hits
+}
+
+// Casts a ray from start along the direction and returns the closest collider it hits
+func (world *World) RaycastFirst(start, direction Vector2f, mask uint32) (RaycastHit, bool) {
+	hits := world.RaycastAll(start, direction, mask)
+
+	if len(hits) == 0 {
+		return RaycastHit{}, false
+	}
+
+	return hits[0], true
+}
+
+// Sweeps the body along the velocity and returns the first collider it hits
+// The point of the hit is where the center of the body is when it hits the collider
+func (world *World) BoxCast(body Body, velocity Vector2f, mask uint32) (RaycastHit, bool) {
+	var closest RaycastHit
+	found := false
+
+	for _, index := range world.nearbyIndices(sweptArea(body, velocity), mask) {
+		collider := world.Colliders[index]
+
+		var collision bool
+		var hitTime float64
+		var contactNormal Vector2f
+
+		if collider.Slope == SlopeNone {
+			collision, hitTime, contactNormal = body.VsBody(collider.Body, velocity)
+		} else {
+			// Cast the center of the body against the slope grown by the size of the body
+			expanded := body.ExpandPolygon(collider.Polygon())
+			collision, hitTime, contactNormal = RayVsPolygon(expanded, body.Center(), velocity)
+		}
+
+		if !collision || (found && hitTime >= closest.Fraction) {
+			continue
+		}
+
+		center := body.Center()
+
+		closest = RaycastHit{
+			Id:       collider.Id,
+			Point:    center.Add(velocity.Scale(hitTime)),
+			Normal:   contactNormal,
+			Fraction: hitTime,
+		}
+		found = true
+	}
+
+	if found {
+		world.Debug.AddRaycast(body.Center(), velocity, []RaycastHit{closest})
+	} else {
+		world.Debug.AddRaycast(body.Center(), velocity, nil)
+	}
+
+	return closest, found
+}
+
+// Returns the entity ids of the colliders which overlap the body
+func (world *World) OverlapBox(body Body, mask uint32) []int {
+	ids := make([]int, 0)
+
+	world.Debug.AddTrigger(body)
+
+	for _, index := range world.nearbyIndices(body, mask) {
+		collider := world.Colliders[index]
+
+		if collider.Slope == SlopeNone {
+			if !body.CollidesWithStaticBody(collider.Body) {
+				continue
+			}
+		} else if !PolygonVsBody(collider.Polygon(), body) {
+			continue
+		}
+
+		ids = append(ids, collider.Id)
+	}
+
+	return ids
+}
+
+// Returns the entity ids of the colliders which overlap the circle
+func (world *World) OverlapCircle(center Vector2f, radius float64, mask uint32) []int {
+	ids := make([]int, 0)
+
+	world.Debug.AddCircle(NewCircle(center, radius))
+
+	// Only check the colliders around the circle
+	area := NewBody(center, NewVector2f(0, 0)).Expand(NewVector2f(radius, radius))
+
+	for _, index := range world.nearbyIndices(area, mask) {
+		collider := world.Colliders[index]
+
+		if PolygonVsCircle(collider.Polygon(), center, radius) {
+			ids = append(ids, collider.Id)
+		}
+	}
+
+	return ids
+}
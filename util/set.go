@@ -125,6 +125,16 @@ func (set *SparseSet[T]) Get(index int) (T, bool) {
 	return *valueAddress, ok
 }
 
+// Returns the indices which have a value, in the order of the dense set
+func (set *SparseSet[T]) Indices() []int {
+	return set.denseToSparse
+}
+
+// Returns the values, in the order of the dense set
+func (set *SparseSet[T]) Values() []T {
+	return set.dense
+}
+
 func (set *SparseSet[T]) Delete(index int) {
 	// Check if the index is valid in the first place
 	_, ok := set.Get(index)
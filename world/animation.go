@@ -0,0 +1,82 @@
+package world
+
+import (
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/physics"
+)
+
+// Events of the animations during the current step, for effects and audio
+type AnimationEvents struct {
+	Events []gfx.AnimationEvent
+}
+
+// Removes the events of the previous step
+func (events *AnimationEvents) Clear() {
+	events.Events = events.Events[:0]
+}
+
+// Advance the animations, and show their frames on the sprites
+// Animators pick the clip from the movement of the entity
+// This MUST be handled AFTER the physics step
+func UpdateAnimations(manager *ecs.Manager) {
+	report := ecs.GetResource[AnimationEvents](manager)
+
+	for _, id := range ecs.GetEntities[gfx.Animation](manager) {
+		animation := ecs.GetComponent[gfx.Animation](manager, id)
+
+		animation.ClearEvents()
+
+		// Pick the clip from the movement, before it is advanced, so a new clip is shown this step
+		facing := 0
+
+		if ecs.HasComponent[gfx.Animator](manager, id) && ecs.HasComponent[physics.Force](manager, id) {
+			animator := ecs.GetComponent[gfx.Animator](manager, id)
+			force := ecs.GetComponent[physics.Force](manager, id)
+
+			var clip string
+			clip, facing = animator.Pick(*force)
+
+			animation.Play(clip)
+		}
+
+		animation.Update()
+
+		// Report the events of the entity
+		for _, event := range animation.Events {
+			event.Id = id
+			report.Events = append(report.Events, event)
+		}
+
+		if !ecs.HasComponent[gfx.Sprite](manager, id) {
+			continue
+		}
+
+		sprite := ecs.GetComponent[gfx.Sprite](manager, id)
+
+		if source, ok := animation.Source(); ok {
+			sprite.Source = source
+		}
+
+		// Face the way the entity moves
+		if facing != 0 {
+			sprite.FlipX = facing < 0
+		}
+	}
+}
+
+// Add an animated sprite sheet, whose clips are picked from the movement of the entity
+func AddAnimator(manager *ecs.Manager, id int, sheet gfx.SpriteSheet) {
+	sprite := ecs.GetComponent[gfx.Sprite](manager, id)
+
+	// Keep the size of the sprite, and draw the frames of the sheet
+	destination := sprite.Destination
+	sprite.Destroy()
+
+	*sprite = gfx.NewSpriteFromPath(sheet.Image)
+	sprite.Destination = destination
+
+	*ecs.AddComponent[gfx.Animation](manager, id) = sheet.Animation()
+	*ecs.AddComponent[gfx.Animator](manager, id) = gfx.NewAnimator()
+}
@@ -0,0 +1,30 @@
+package world
+
+import (
+	// Game packages
+	"github.com/plutial/game/physics"
+)
+
+// Contacts of every entity during the last physics step
+// The contacts of a single entity are in the collisions of its force
+type ContactReport struct {
+	Contacts []physics.Contact
+}
+
+// Removes the contacts of the previous step
+func (report *ContactReport) Clear() {
+	report.Contacts = report.Contacts[:0]
+}
+
+// Returns the contacts with the entity, as either the moving body or the collider
+func (report ContactReport) With(id int) []physics.Contact {
+	contacts := make([]physics.Contact, 0)
+
+	for _, contact := range report.Contacts {
+		if contact.Id == id || contact.OtherId == id {
+			contacts = append(contacts, contact)
+		}
+	}
+
+	return contacts
+}
@@ -0,0 +1,106 @@
+package world
+
+import (
+	"image/color"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/physics"
+)
+
+// Colors of the colliders on each layer
+var layerColors = map[uint32]color.RGBA{
+	physics.LayerTile:       {0, 160, 255, 255},
+	physics.LayerPlatform:   {255, 160, 0, 255},
+	physics.LayerPlayer:     {0, 255, 0, 255},
+	physics.LayerEnemy:      {255, 0, 0, 255},
+	physics.LayerProjectile: {255, 255, 0, 255},
+	physics.LayerClimbable:  {160, 96, 255, 255},
+	physics.LayerFluid:      {40, 100, 255, 255},
+	physics.LayerGravity:    {255, 0, 255, 255},
+	physics.LayerForceField: {0, 255, 255, 255},
+}
+
+// Colors of the recorded shapes
+var (
+	broadPhaseColor = color.RGBA{96, 96, 96, 255}
+	contactColor    = color.RGBA{255, 0, 255, 255}
+	normalColor     = color.RGBA{255, 255, 0, 255}
+	velocityColor   = color.RGBA{0, 255, 255, 255}
+	raycastColor    = color.RGBA{255, 255, 255, 255}
+	triggerColor    = color.RGBA{255, 128, 128, 255}
+)
+
+// Toggle the physics debug overlay
+func ToggleDebug(manager *ecs.Manager) {
+	debug := ecs.GetResource[physics.DebugData](manager)
+	debug.Enabled = !debug.Enabled
+}
+
+// Draw the colliders and the shapes recorded during the physics step on top of the game
+func RenderDebug(manager *ecs.Manager) {
+	debug := ecs.GetResource[physics.DebugData](manager)
+
+	if !debug.Enabled {
+		return
+	}
+
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	// Only the colliders on the screen are drawn
+	colliders := physicsWorld.Colliders
+
+	if ecs.HasResource[gfx.Camera](manager) {
+		colliders = physicsWorld.Nearby(ecs.GetResource[gfx.Camera](manager).VisibleArea(), physics.LayerAll)
+	}
+
+	// Outlines of the colliders, colored by layer
+	for _, collider := range colliders {
+		outline := collider.Polygon()
+
+		// Round entities are drawn with their shape
+		if manager.IsEntityAlive(collider.Id) && ecs.HasComponent[physics.BodyShape](manager, collider.Id) {
+			bodyShape := ecs.GetComponent[physics.BodyShape](manager, collider.Id)
+			outline = physics.ShapePolygon(bodyShape.Fit(collider.Body))
+		}
+
+		gfx.RenderPolygonOutline(layerColors[collider.Layer], outline)
+	}
+
+	// Broad phase areas
+	for _, area := range debug.BroadPhases {
+		gfx.RenderRectangleOutline(broadPhaseColor, area)
+	}
+
+	// Contact points and their normals
+	for _, contact := range debug.Contacts {
+		point := contact.Point
+
+		gfx.RenderRectangle(contactColor, physics.NewBody(point, physics.NewVector2f(0, 0)).Expand(physics.NewVector2f(1, 1)), 0)
+		gfx.RenderLine(normalColor, point, point.Add(contact.Normal.Scale(8)))
+	}
+
+	// Velocities
+	for _, velocity := range debug.Velocities {
+		gfx.RenderLine(velocityColor, velocity.Start, velocity.End)
+	}
+
+	// Raycasts, and where they hit
+	for _, ray := range debug.Raycasts {
+		gfx.RenderLine(raycastColor, ray.Start, ray.End)
+
+		if ray.Hit {
+			gfx.RenderCircleOutline(contactColor, ray.Point, 2)
+		}
+	}
+
+	// Overlap queries
+	for _, area := range debug.Triggers {
+		gfx.RenderRectangleOutline(triggerColor, area)
+	}
+
+	for _, circle := range debug.Circles {
+		gfx.RenderCircleOutline(triggerColor, circle.Center, circle.Radius)
+	}
+}
@@ -18,7 +18,7 @@ func NewEnemy(manager *ecs.Manager) {
 	// Add components
 	sprite := ecs.AddComponent[gfx.Sprite](manager, id)
 
-	*sprite = gfx.NewSprite(gfx.NewTexture("assets/res/image.png"))
+	*sprite = gfx.NewSpriteFromPath("assets/res/image.png")
 
 	// Body
 	body := ecs.AddComponent[physics.Body](manager, id)
@@ -33,6 +33,36 @@ func NewEnemy(manager *ecs.Manager) {
 
 	*force = physics.NewForce(physics.NewVector2f(0, 0), physics.NewVector2f(0, 0))
 
-	// Jump
-	ecs.AddComponent[physics.Jump](manager, id)
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyDynamic)
+
+	// Controller
+	controller := ecs.AddComponent[physics.PlatformerController](manager, id)
+	*controller = physics.NewPlatformerController()
+}
+
+// Moves the enemies by their controllers
+// Enemies don't press any buttons yet, so they only land, fall and slide along the ground
+// This MUST be handled BEFORE the physics update, like the movement of the player
+func UpdateEnemies(manager *ecs.Manager) {
+	enemies := ecs.GetEntities2[EnemyTag, physics.PlatformerController](manager)
+
+	settings := ecs.GetResource[physics.PhysicsSettings](manager)
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	for _, id := range enemies {
+		if !ecs.HasComponent[physics.Body](manager, id) || !ecs.HasComponent[physics.Force](manager, id) {
+			continue
+		}
+
+		body := ecs.GetComponent[physics.Body](manager, id)
+		force := ecs.GetComponent[physics.Force](manager, id)
+		controller := ecs.GetComponent[physics.PlatformerController](manager, id)
+
+		// The walls, ledges and ladders around the enemy
+		area := body.Expand(body.Size)
+		colliders := physicsWorld.Nearby(area, physics.LayerTile|physics.LayerPlatform)
+		ladders := physicsWorld.Nearby(area, physics.LayerClimbable)
+
+		controller.Update(body, force, *settings, physics.ControllerInput{}, colliders, ladders)
+	}
 }
@@ -0,0 +1,94 @@
+package world
+
+import (
+	"strings"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/physics"
+)
+
+// Collision layers by their names in the map data
+var layerNames = map[string]uint32{
+	"tile":       physics.LayerTile,
+	"platform":   physics.LayerPlatform,
+	"player":     physics.LayerPlayer,
+	"enemy":      physics.LayerEnemy,
+	"projectile": physics.LayerProjectile,
+}
+
+// Falloffs by the falloff property of the object
+var falloffs = map[string]int{
+	"none":      physics.FalloffNone,
+	"linear":    physics.FalloffLinear,
+	"quadratic": physics.FalloffQuadratic,
+}
+
+// Returns the mask of the comma separated layer names
+// An empty list is every layer
+func LayerMask(names string) uint32 {
+	if strings.TrimSpace(names) == "" {
+		return physics.LayerAll
+	}
+
+	var mask uint32
+
+	for _, name := range strings.Split(names, ",") {
+		mask |= layerNames[strings.TrimSpace(name)]
+	}
+
+	return mask
+}
+
+// Create a force field which covers the area
+func NewForceFieldArea(manager *ecs.Manager, position, size physics.Vector2f, field physics.ForceField) int {
+	id := manager.NewEntity()
+
+	// Body
+	body := ecs.AddComponent[physics.Body](manager, id)
+	*body = physics.NewBody(position, size)
+
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyStatic)
+
+	// Force field
+	*ecs.AddComponent[physics.ForceField](manager, id) = field
+
+	return id
+}
+
+// Returns the colliders of the force fields
+func GetForceFields(manager *ecs.Manager) []physics.Collider {
+	fields := ecs.GetEntities2[physics.ForceField, physics.Body](manager)
+
+	var colliders []physics.Collider
+
+	for _, id := range fields {
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.Layer = physics.LayerForceField
+
+		colliders = append(colliders, collider)
+	}
+
+	return colliders
+}
+
+// Pushes the entity by the force fields it is in
+// Fields only push the entities on the layers of their mask
+func ApplyForceFields(manager *ecs.Manager, id int, body physics.Body, force *physics.Force) {
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	layer := GetLayer(manager, id)
+
+	for _, collider := range physicsWorld.Nearby(body, physics.LayerForceField) {
+		field := ecs.GetComponent[physics.ForceField](manager, collider.Id)
+
+		if field.Mask&layer == 0 {
+			continue
+		}
+
+		force.ApplyForce(field.PushAt(collider.Body, body))
+	}
+}
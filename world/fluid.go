@@ -0,0 +1,108 @@
+package world
+
+import (
+	"image/color"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/physics"
+)
+
+// Splashes of the bodies entering and leaving the fluids during the current step
+type Splashes struct {
+	Events []physics.SplashEvent
+}
+
+// Removes the splashes of the previous step
+func (splashes *Splashes) Clear() {
+	splashes.Events = splashes.Events[:0]
+}
+
+// Create a fluid volume which covers the area
+func NewFluidVolume(manager *ecs.Manager, position, size physics.Vector2f, fluid physics.Fluid) int {
+	id := manager.NewEntity()
+
+	// Add components
+	sprite := ecs.AddComponent[gfx.Sprite](manager, id)
+	*sprite = gfx.NewSprite(nil)
+	sprite.Color = color.RGBA{40, 100, 255, 120}
+	sprite.Destination.Position = position
+	sprite.Destination.Size = size
+
+	// Body
+	body := ecs.AddComponent[physics.Body](manager, id)
+	*body = physics.NewBody(position, size)
+
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyStatic)
+
+	// Fluid
+	*ecs.AddComponent[physics.Fluid](manager, id) = fluid
+
+	return id
+}
+
+// Returns the colliders of the fluid volumes
+func GetFluids(manager *ecs.Manager) []physics.Collider {
+	fluids := ecs.GetEntities2[physics.Fluid, physics.Body](manager)
+
+	var colliders []physics.Collider
+
+	for _, id := range fluids {
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.Layer = physics.LayerFluid
+
+		colliders = append(colliders, collider)
+	}
+
+	return colliders
+}
+
+// Pushes the body up and slows it down by the fluids it is in, and records the splashes
+// The gravity is the gravity which pulls the body down this step
+func UpdateBuoyancy(manager *ecs.Manager, id int, body *physics.Body, force *physics.Force, gravity physics.Vector2f, material physics.PhysicsMaterial) {
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	submerged := 0.0
+	fluidId := force.Fluid
+
+	// The speed the body hits the surface at, before the fluid slows it down
+	speed := force.Acceleration.Y
+
+	for _, collider := range physicsWorld.Nearby(*body, physics.LayerFluid) {
+		amount := body.Submerged(collider.Body)
+
+		if amount == 0 {
+			continue
+		}
+
+		fluid := ecs.GetComponent[physics.Fluid](manager, collider.Id)
+		force.ApplyFluid(*fluid, amount, gravity, material)
+
+		submerged += amount
+		fluidId = collider.Id
+	}
+
+	// Overlapping fluids can't cover more than the whole body
+	submerged = min(1, submerged)
+
+	// Splash when the body enters or leaves the fluid
+	if (submerged > 0) != (force.Submerged > 0) && manager.IsEntityAlive(fluidId) {
+		surface := ecs.GetComponent[physics.Body](manager, fluidId).Position.Y
+
+		splashes := ecs.GetResource[Splashes](manager)
+		splashes.Events = append(splashes.Events, physics.SplashEvent{
+			Id:      id,
+			Fluid:   fluidId,
+			Point:   physics.NewVector2f(body.Center().X, surface),
+			Speed:   speed,
+			Entered: submerged > 0,
+		})
+	}
+
+	force.Submerged = submerged
+	force.Fluid = fluidId
+}
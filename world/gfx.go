@@ -7,6 +7,26 @@ import (
 	"github.com/plutial/game/physics"
 )
 
+// Follow the player with the camera, looking ahead in the direction the player moves
+func UpdateCamera(manager *ecs.Manager) {
+	if !ecs.HasResource[gfx.Camera](manager) {
+		return
+	}
+
+	camera := ecs.GetResource[gfx.Camera](manager)
+
+	players := ecs.GetEntities[PlayerTag](manager)
+
+	if len(players) > 0 {
+		body := ecs.GetComponent[physics.Body](manager, players[0])
+		force := ecs.GetComponent[physics.Force](manager, players[0])
+
+		camera.Follow(body.Center(), force.Acceleration)
+	}
+
+	camera.Update()
+}
+
 func UpdateSprite(manager *ecs.Manager) {
 	// Get all the entities which have the sprite component and the body component
 	entities := ecs.GetEntities2[gfx.Sprite, physics.Body](manager)
@@ -18,5 +38,11 @@ func UpdateSprite(manager *ecs.Manager) {
 
 		// Update the position of the sprite
 		sprite.Destination.Position = body.Position
+
+		// Controlled entities stand up against their gravity
+		if ecs.HasComponent[physics.PlatformerController](manager, id) {
+			force := ecs.GetComponent[physics.Force](manager, id)
+			sprite.Rotation = physics.GravityRotation(force.Gravity)
+		}
 	}
 }
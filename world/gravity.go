@@ -0,0 +1,68 @@
+package world
+
+import (
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/physics"
+)
+
+// Create a gravity zone which covers the area
+func NewGravityArea(manager *ecs.Manager, position, size physics.Vector2f, zone physics.GravityZone) int {
+	id := manager.NewEntity()
+
+	// Body
+	body := ecs.AddComponent[physics.Body](manager, id)
+	*body = physics.NewBody(position, size)
+
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyStatic)
+
+	// Gravity zone
+	*ecs.AddComponent[physics.GravityZone](manager, id) = zone
+
+	return id
+}
+
+// Returns the colliders of the gravity zones
+func GetGravityZones(manager *ecs.Manager) []physics.Collider {
+	zones := ecs.GetEntities2[physics.GravityZone, physics.Body](manager)
+
+	var colliders []physics.Collider
+
+	for _, id := range zones {
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.Layer = physics.LayerGravity
+
+		colliders = append(colliders, collider)
+	}
+
+	return colliders
+}
+
+// Returns the gravity at the center of the body
+// The zone with the highest priority wins, and the gravity of the world is used outside of the zones
+func GetGravity(manager *ecs.Manager, body physics.Body) physics.Vector2f {
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	gravity := ecs.GetResource[physics.PhysicsSettings](manager).Gravity
+	priority := 0
+	found := false
+
+	for _, collider := range physicsWorld.Nearby(body, physics.LayerGravity) {
+		if !collider.Body.Contains(body.Center()) {
+			continue
+		}
+
+		zone := ecs.GetComponent[physics.GravityZone](manager, collider.Id)
+
+		if !found || zone.Priority > priority {
+			gravity = zone.Gravity
+			priority = zone.Priority
+			found = true
+		}
+	}
+
+	return gravity
+}
@@ -0,0 +1,28 @@
+package world
+
+import (
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/physics"
+)
+
+// Pushes the entities hit by the radial impulse, and returns their ids
+func ApplyRadialImpulse(manager *ecs.Manager, impulse physics.RadialImpulse) []int {
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	ids := make([]int, 0)
+
+	for _, hit := range physicsWorld.RadialImpulse(impulse) {
+		// Only moving entities can be pushed
+		if !ecs.HasComponent[physics.Force](manager, hit.Id) {
+			continue
+		}
+
+		force := ecs.GetComponent[physics.Force](manager, hit.Id)
+		force.ApplyImpulse(hit.Impulse, GetMaterial(manager, hit.Id))
+
+		ids = append(ids, hit.Id)
+	}
+
+	return ids
+}
@@ -0,0 +1,57 @@
+package world
+
+import (
+	// Game packages
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/input"
+	"github.com/plutial/game/physics"
+)
+
+// Input of the player for one step
+// The systems read the input from here instead of from the devices,
+// so recorded input can be played back and gives the same results
+type PlayerInput struct {
+	// Movement buttons
+	Controller physics.ControllerInput
+
+	// The attack button was pressed this step
+	Attack bool
+
+	// Where the player is aiming
+	Aim physics.Vector2f
+
+	// The grappling hook was shot this step, and is being held
+	Grapple, GrappleHeld bool
+
+	// The rope of the grappling hook is being reeled in or out
+	ReelIn, ReelOut bool
+}
+
+// Read the input of the player from the keyboard and mouse
+// Jumping has its own key, so holding up to climb ledges and ladders does not jump
+// The mouse is turned into a point in the world through the camera
+func ReadPlayerInput(camera gfx.Camera) PlayerInput {
+	playerInput := PlayerInput{}
+
+	playerInput.Controller = physics.ControllerInput{
+		Left:        input.IsKeyDown(input.KeyA),
+		Right:       input.IsKeyDown(input.KeyD),
+		Up:          input.IsKeyDown(input.KeyW),
+		Down:        input.IsKeyDown(input.KeyS),
+		JumpPressed: input.IsKeyPressed(input.KeySpace),
+		JumpHeld:    input.IsKeyDown(input.KeySpace),
+		DashPressed: input.IsKeyPressed(input.KeyShift),
+	}
+
+	playerInput.Attack = input.IsMouseButtonPressed(input.MouseButtonLeft)
+	playerInput.Aim = camera.ScreenToWorld(input.MousePosition())
+
+	playerInput.Grapple = input.IsMouseButtonPressed(input.MouseButtonRight)
+	playerInput.GrappleHeld = input.IsMouseButtonDown(input.MouseButtonRight)
+
+	// Reeling has its own keys, so it does not also jump or drop through platforms
+	playerInput.ReelIn = input.IsKeyDown(input.KeyE)
+	playerInput.ReelOut = input.IsKeyDown(input.KeyQ)
+
+	return playerInput
+}
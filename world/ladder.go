@@ -0,0 +1,68 @@
+package world
+
+import (
+	"image/color"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/physics"
+)
+
+// Climbable zones can be climbed up and down, and stood on from the top
+type ClimbableTag bool
+
+// Create a ladder which covers the area
+func NewLadder(manager *ecs.Manager, position, size physics.Vector2f) int {
+	id := manager.NewEntity()
+
+	ecs.AddComponent[ClimbableTag](manager, id)
+
+	// Add components
+	sprite := ecs.AddComponent[gfx.Sprite](manager, id)
+	*sprite = gfx.NewSprite(nil)
+	sprite.Color = color.RGBA{160, 110, 60, 255}
+	sprite.Destination.Position = position
+	sprite.Destination.Size = size
+
+	// Body
+	body := ecs.AddComponent[physics.Body](manager, id)
+	*body = physics.NewBody(position, size)
+
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyStatic)
+
+	return id
+}
+
+// Returns the colliders of the ladders
+// The top of each ladder is also a one-way platform, so it can be stood on
+func GetLadders(manager *ecs.Manager) []physics.Collider {
+	// Get all the ladders
+	ladders := ecs.GetEntities2[ClimbableTag, physics.Body](manager)
+
+	var zones []physics.Collider
+
+	for _, id := range ladders {
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.Layer = physics.LayerClimbable
+
+		zones = append(zones, collider)
+	}
+
+	var tops []physics.Collider
+
+	// Ladders made of tiles are only stood on from the highest tile
+	for _, zone := range physics.LadderTops(zones) {
+		top := zone
+		top.OneWay = true
+		top.Layer = physics.LayerPlatform
+		top.Material = GetMaterial(manager, zone.Id)
+
+		tops = append(tops, top)
+	}
+
+	return append(zones, tops...)
+}
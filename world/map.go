@@ -2,8 +2,11 @@ package world
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"io/ioutil"
 	"log"
+	"path/filepath"
+	"strconv"
 
 	// Game packages
 	"github.com/plutial/game/ecs"
@@ -22,15 +25,121 @@ type GameMapData struct {
 
 	// Tile layers
 	TileLayers []TileLayerData `json:"layers"`
+
+	// Tilesets used by the map
+	Tilesets []TilesetReferenceData `json:"tilesets"`
 }
 
 type TileLayerData struct {
 	Data []int  `json:"data"`
 	Name string `json:"name"`
+
+	// Either "tilelayer" or "objectgroup"
+	Type string `json:"type"`
+
+	// Object layers
+	Objects []ObjectData `json:"objects"`
+}
+
+// External tilesets are stored in a separate file
+type TilesetReferenceData struct {
+	FirstId int    `json:"firstgid"`
+	Source  string `json:"source"`
+}
+
+// Tiled tileset file (.tsx)
+type TilesetData struct {
+	Tiles []TileData `xml:"tile"`
+}
+
+// Custom properties of a tile in the tileset
+type TileData struct {
+	Id         int                `xml:"id,attr"`
+	Properties []TilePropertyData `xml:"properties>property"`
+}
+
+type TilePropertyData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type TileTag bool
 
+// One-way platforms can be jumped through from below and dropped through from above
+type PlatformTag bool
+
+// Slope shapes by the collision property of the tile
+var tileSlopes = map[string]physics.Slope{
+	"slope_floor_right_45":        physics.SlopeFloorRight45,
+	"slope_floor_left_45":         physics.SlopeFloorLeft45,
+	"slope_floor_right_22_low":    physics.SlopeFloorRight22Low,
+	"slope_floor_right_22_high":   physics.SlopeFloorRight22High,
+	"slope_floor_left_22_low":     physics.SlopeFloorLeft22Low,
+	"slope_floor_left_22_high":    physics.SlopeFloorLeft22High,
+	"slope_ceiling_right_45":      physics.SlopeCeilingRight45,
+	"slope_ceiling_left_45":       physics.SlopeCeilingLeft45,
+	"slope_ceiling_right_22_low":  physics.SlopeCeilingRight22Low,
+	"slope_ceiling_right_22_high": physics.SlopeCeilingRight22High,
+	"slope_ceiling_left_22_low":   physics.SlopeCeilingLeft22Low,
+	"slope_ceiling_left_22_high":  physics.SlopeCeilingLeft22High,
+}
+
+// Returns the material of a tile from its friction, restitution and conveyor properties
+// Returns false if the tile uses the default material
+func TileMaterial(properties map[string]string, material physics.PhysicsMaterial) (physics.PhysicsMaterial, bool) {
+	found := false
+
+	if value, err := strconv.ParseFloat(properties["friction"], 64); err == nil {
+		material.Friction = value
+		found = true
+	}
+
+	if value, err := strconv.ParseFloat(properties["restitution"], 64); err == nil {
+		material.Restitution = value
+		found = true
+	}
+
+	// Conveyor belts carry the bodies on them along their surface
+	if value, err := strconv.ParseFloat(properties["conveyor"], 64); err == nil {
+		material.SurfaceSpeed = value
+		found = true
+	}
+
+	return material, found
+}
+
+// Load the custom properties of each tile from a tileset
+// The properties are indexed by the tile source id used in the map data
+func LoadTileProperties(path string, firstId int) map[int]map[string]string {
+	// Open the xml file
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tilesetData TilesetData
+
+	// A broken tileset would lose the collisions and materials of every tile
+	if err := xml.Unmarshal(data, &tilesetData); err != nil {
+		log.Fatal(err)
+	}
+
+	properties := make(map[int]map[string]string)
+
+	for _, tile := range tilesetData.Tiles {
+		tileProperties := make(map[string]string)
+
+		for _, property := range tile.Properties {
+			tileProperties[property.Name] = property.Value
+		}
+
+		// The map data is offset by the first id of the tileset
+		properties[tile.Id+firstId] = tileProperties
+	}
+
+	return properties
+}
+
 func LoadMap(manager *ecs.Manager, path string) {
 	// Open the json file
 	data, err := ioutil.ReadFile(path)
@@ -44,7 +153,21 @@ func LoadMap(manager *ecs.Manager, path string) {
 	json.Unmarshal(data, &gameMapData)
 
 	// Load the tile texture
-	tileTexture := gfx.NewTexture("assets/res/GrassTiles.png")
+	tilePath := "assets/res/GrassTiles.png"
+	tileTexture := gfx.Assets.Texture(tilePath)
+	defer gfx.Assets.Release(tilePath)
+
+	// Load the tile properties
+	// The tileset path is relative to the map
+	tileProperties := make(map[int]map[string]string)
+
+	if len(gameMapData.Tilesets) > 0 {
+		tileset := gameMapData.Tilesets[0]
+		tileProperties = LoadTileProperties(filepath.Join(filepath.Dir(path), tileset.Source), tileset.FirstId)
+	}
+
+	// Materials which are not set by the tiles are taken from the default material
+	settings := ecs.GetResource[physics.PhysicsSettings](manager)
 
 	// Put the tiles into the world
 	for y := range gameMapData.LayerHeight {
@@ -59,13 +182,21 @@ func LoadMap(manager *ecs.Manager, path string) {
 			// Create an entity
 			id := manager.NewEntity()
 
+			// Collision type of the tile
+			collision := tileProperties[tileSourceId]["collision"]
+
 			// Add the tile tag
-			ecs.AddComponent[TileTag](manager, id)
+			// Ladders are climbed through, so they are not solid tiles
+			if collision == "ladder" {
+				ecs.AddComponent[ClimbableTag](manager, id)
+			} else {
+				ecs.AddComponent[TileTag](manager, id)
+			}
 
 			// Create the sprite component
 			sprite := ecs.AddComponent[gfx.Sprite](manager, id)
 
-			*sprite = gfx.NewSprite(tileTexture)
+			*sprite = gfx.NewSpriteFromPath(tilePath)
 
 			// Source rectangle
 			textureSize := physics.NewVector2f(
@@ -89,6 +220,39 @@ func LoadMap(manager *ecs.Manager, path string) {
 			size := physics.NewVector2f(16, 16)
 
 			*body = physics.NewBody(position, size)
+
+			// Tiles never move
+			*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyStatic)
+
+			switch collision {
+			case "oneway":
+				ecs.AddComponent[PlatformTag](manager, id)
+			default:
+				// Sloped tiles
+				if slope, ok := tileSlopes[collision]; ok {
+					*ecs.AddComponent[physics.Slope](manager, id) = slope
+				}
+			}
+
+			// Icy and bouncy tiles
+			if material, ok := TileMaterial(tileProperties[tileSourceId], settings.DefaultMaterial); ok {
+				*ecs.AddComponent[physics.PhysicsMaterial](manager, id) = material
+			}
 		}
 	}
+
+	// Load the objects from the object layers
+	for _, layer := range gameMapData.TileLayers {
+		if layer.Type == "objectgroup" {
+			LoadObjects(manager, layer.Objects)
+		}
+	}
+
+	// Keep the view inside the map
+	if ecs.HasResource[gfx.Camera](manager) {
+		ecs.GetResource[gfx.Camera](manager).Bounds = physics.NewBody(
+			physics.NewVector2f(0, 0),
+			physics.NewVector2f(float64(gameMapData.LayerWidth)*16, float64(gameMapData.LayerHeight)*16),
+		)
+	}
 }
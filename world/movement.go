@@ -2,12 +2,10 @@ package world
 
 import (
 	"image/color"
-	"math"
 
 	// Game packages
 	"github.com/plutial/game/ecs"
 	"github.com/plutial/game/gfx"
-	"github.com/plutial/game/input"
 	"github.com/plutial/game/physics"
 )
 
@@ -16,21 +14,41 @@ func UpdateMovement(manager *ecs.Manager) {
 	// Get the player id
 	playerId := ecs.GetEntities[PlayerTag](manager)[0]
 
+	body := ecs.GetComponent[physics.Body](manager, playerId)
 	force := ecs.GetComponent[physics.Force](manager, playerId)
+	controller := ecs.GetComponent[physics.PlatformerController](manager, playerId)
+	settings := ecs.GetResource[physics.PhysicsSettings](manager)
 
-	// Horizontal movement
-	force.Move(input.IsKeyDown(input.KeyA), input.IsKeyDown(input.KeyD))
-	force.Dash(input.IsKeyDown(input.KeyA), input.IsKeyDown(input.KeyD), input.IsKeyPressed(input.KeySpace))
+	// The walls and ledges around the player
+	physicsWorld := ecs.GetResource[physics.World](manager)
 
-	// Update jumps
-	jump := ecs.GetComponent[physics.Jump](manager, playerId)
+	area := body.Expand(body.Size)
+	colliders := physicsWorld.Nearby(area, physics.LayerTile|physics.LayerPlatform)
+	ladders := physicsWorld.Nearby(area, physics.LayerClimbable)
 
-	force.Jump(jump, input.IsKeyPressed(input.KeyW))
+	// The buttons which control the player
+	controllerInput := ecs.GetResource[PlayerInput](manager).Controller
+
+	controller.Update(body, force, *settings, controllerInput, colliders, ladders)
+
+	// Dashing overrides the movement of the controller
+	if ecs.HasComponent[physics.Dash](manager, playerId) {
+		dash := ecs.GetComponent[physics.Dash](manager, playerId)
+
+		// Grabbing a ledge or a ladder stops the dash, and the player can't dash while holding on
+		// The timers keep counting down
+		if controller.Hanging != physics.SideNone || controller.Climbing {
+			dash.Cancel()
+			dash.Update(force, physics.ControllerInput{})
+		} else {
+			dash.Update(force, controllerInput)
+		}
+	}
 }
 
 func EntityAttack(manager *ecs.Manager) {
 	// Dismiss if the player does not attack
-	if !input.IsMouseButtonPressed(input.MouseButtonLeft) {
+	if !ecs.GetResource[PlayerInput](manager).Attack {
 		return
 	}
 
@@ -38,67 +56,24 @@ func EntityAttack(manager *ecs.Manager) {
 	playerId := ecs.GetEntities[PlayerTag](manager)[0]
 
 	playerBody := ecs.GetComponent[physics.Body](manager, playerId)
+	settings := ecs.GetResource[physics.PhysicsSettings](manager)
 
-	// Center of the player body
-	center := playerBody.Center()
-
-	// Get the enemies
-	enemies := ecs.GetEntities[EnemyTag](manager)
-
-	for _, enemyId := range enemies {
-		enemyBody := ecs.GetComponent[physics.Body](manager, enemyId)
-		enemyForce := ecs.GetComponent[physics.Force](manager, enemyId)
-
-		// Raycast an attack if the enemy is in range
-		if playerBody.Position.Distance(enemyBody.Position) < 80 {
-			movement := physics.NewVector2f(
-				enemyBody.Center().X-playerBody.Center().X,
-				enemyBody.Center().Y-playerBody.Center().Y,
-			)
-
-			// Check if the ray is blocked by any of the tiles
-			blocked := false
-
-			tiles := ecs.GetEntities[TileTag](manager)
-
-			for _, tileId := range tiles {
-				tileBody := ecs.GetComponent[physics.Body](manager, tileId)
-
-				// Carry out a broad phase to stop handling
-				// Minimize expensive physics on absurd tiles that will never collide with
-				collision := playerBody.BroadPhase(*tileBody, movement)
-
-				if !collision {
-					continue
-				}
-
-				// Check for collision
-				collision, _, _ = tileBody.VsRay(center, movement)
-
-				if collision {
-					blocked = true
-					break
-				}
-			}
-
-			if !blocked {
-				if playerBody.Position.X-enemyBody.Position.X > 0 {
-					enemyForce.Velocity.X = -30
-				} else {
-					enemyForce.Velocity.X = 30
-				}
+	// Knock the enemies in reach away from the player, unless a tile is in the way
+	knockback := physics.NewRadialImpulse(playerBody.Center(), settings.KnockbackRadius, settings.KnockbackStrength)
+	knockback.Falloff = physics.FalloffNone
+	knockback.Lift = settings.KnockbackLift
+	knockback.Mask = physics.LayerEnemy
+	knockback.BlockMask = physics.LayerTile
 
-				enemyForce.Velocity.Y = -30
-				enemyForce.Acceleration.Y = -0.6
-			}
-		}
-	}
+	ApplyRadialImpulse(manager, knockback)
 }
 
 type ProjectileTag bool
 
 func EntityCharge(manager *ecs.Manager) {
-	if input.IsMouseButtonPressed(input.MouseButtonLeft) {
+	playerInput := ecs.GetResource[PlayerInput](manager)
+
+	if playerInput.Attack {
 		// Create a new charge projectile
 		id := manager.NewEntity()
 
@@ -116,77 +91,71 @@ func EntityCharge(manager *ecs.Manager) {
 			physics.NewVector2f(8, 8),
 		)
 
+		// Projectiles are round
+		shape := ecs.AddComponent[physics.BodyShape](manager, id)
+		*shape = physics.NewBodyShape(physics.ShapeCircle)
+
+		// Projectiles are fast, so they are swept in steps no longer than half their size
+		bullet := ecs.AddComponent[physics.Bullet](manager, id)
+		*bullet = physics.NewBullet(4)
+
 		// Make the projectile go in the position of the mouse
 		force := ecs.AddComponent[physics.Force](manager, id)
 
+		// The projectile starts at the center of the player
 		projectileSpeed := 1.5
-		center := body.Center()
-		center.X -= body.Size.X / 2
-		center.Y -= body.Size.Y / 2
-		center.X += force.Acceleration.X
-		center.Y += force.Acceleration.Y
-		scaleFactor := projectileSpeed / center.Distance(input.MousePosition())
-
-		force.Acceleration.X = input.MousePosition().X - center.X
-		force.Acceleration.X *= scaleFactor
-		force.Acceleration.Y = input.MousePosition().Y - center.Y
-		force.Acceleration.Y *= scaleFactor
+		force.Acceleration = playerInput.Aim.Sub(playerBody.Center()).Normalize().Scale(projectileSpeed)
+
+		*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyDynamic)
 
 		// Add a sprite
 		sprite := ecs.AddComponent[gfx.Sprite](manager, id)
-		*sprite = gfx.NewSprite(gfx.NewTexture("assets/res/image.png"))
-		sprite.Image = nil
+		*sprite = gfx.NewSprite(nil)
 		sprite.Color = color.RGBA{255, 255, 255, 255}
 		sprite.Destination.Size = physics.NewVector2f(8, 8)
 
-		// The rotation
-		// The vertical acceleration is equal to the length opposite side
-		// The horizontal acceleration is to the length adjacent side
-		sprite.Rotation = math.Atan(force.Acceleration.Y / force.Acceleration.X)
+		// The rotation follows the direction of the projectile
+		sprite.Rotation = force.Acceleration.Angle()
 	}
 
 	// Get projectiles
 	projectiles := ecs.GetEntities[ProjectileTag](manager)
 
+	// Contacts of the last physics step, where the projectiles hit
+	report := ecs.GetResource[ContactReport](manager)
+
 	for _, id := range projectiles {
 		// Check to see if the projectile collided with anything
-		force := ecs.GetComponent[physics.Force](manager, id)
+		bullet := ecs.GetComponent[physics.Bullet](manager, id)
+
+		// Knock back the enemies which were hit directly, by how hard the projectile hit them
+		for _, contact := range report.With(id) {
+			if contact.Id != id || !manager.IsEntityAlive(contact.OtherId) || !ecs.HasComponent[EnemyTag](manager, contact.OtherId) {
+				continue
+			}
+
+			if ecs.HasComponent[physics.Force](manager, contact.OtherId) {
+				enemyForce := ecs.GetComponent[physics.Force](manager, contact.OtherId)
+				enemyForce.ApplyImpulse(contact.Normal.Neg().Scale(contact.ImpactSpeed()), GetMaterial(manager, contact.OtherId))
+			}
+		}
 
 		// Boost the entities with an explosion
-		if force.Collisions.Collided() {
-			// Projectile body
-			body := ecs.GetComponent[physics.Body](manager, id)
-
-			// Entities which have a body and a velocity
-			entities := ecs.GetEntities2[physics.Body, physics.Force](manager)
-
-			for _, id := range entities {
-				// The explosion does not affect projectiles
-				if ecs.HasComponent[ProjectileTag](manager, id) {
-					continue
-				}
-
-				entityBody := ecs.GetComponent[physics.Body](manager, id)
-				entityForce := ecs.GetComponent[physics.Force](manager, id)
-
-				explosion := physics.NewVector2f(5, 6.5)
-				// If the entity is in range
-				center := body.Center()
-				center.X -= body.Size.X / 2
-				center.Y -= body.Size.Y / 2
-				if center.Distance(entityBody.Center()) < 32 {
-					if body.Center().X-entityBody.Center().X > 0 {
-						entityForce.Acceleration.X -= explosion.X
-					} else {
-						entityForce.Acceleration.X += explosion.X
-					}
-
-					if body.Center().Y-entityBody.Center().Y > 0 {
-						entityForce.Acceleration.Y -= explosion.Y
-					} else {
-						entityForce.Acceleration.Y += explosion.Y
-					}
-				}
+		if bullet.Hit {
+			// The explosion starts where the projectile hit
+			impact := bullet.Point
+
+			// The explosion pushes the player and the enemies, but not the other projectiles
+			settings := ecs.GetResource[physics.PhysicsSettings](manager)
+
+			explosion := physics.NewRadialImpulse(impact, settings.ExplosionRadius, settings.ExplosionStrength)
+			explosion.Mask = physics.LayerPlayer | physics.LayerEnemy
+
+			ApplyRadialImpulse(manager, explosion)
+
+			// Shake the view
+			if ecs.HasResource[gfx.Camera](manager) {
+				ecs.GetResource[gfx.Camera](manager).AddTrauma(0.3)
 			}
 
 			// Remove the projectile
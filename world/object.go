@@ -0,0 +1,185 @@
+package world
+
+import (
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/physics"
+)
+
+// Object in an object layer of the map
+type ObjectData struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// Position and size
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+
+	// Points of a polyline, relative to the position of the object
+	Polyline []PointData `json:"polyline"`
+
+	// Custom properties
+	Properties []ObjectPropertyData `json:"properties"`
+}
+
+type PointData struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type ObjectPropertyData struct {
+	Name string `json:"name"`
+
+	// Either a string, a number or a boolean
+	Value any `json:"value"`
+}
+
+// Returns the custom property, or the fallback if the object does not have it
+func (object ObjectData) FloatProperty(name string, fallback float64) float64 {
+	for _, property := range object.Properties {
+		if value, ok := property.Value.(float64); ok && property.Name == name {
+			return value
+		}
+	}
+
+	return fallback
+}
+
+// Returns the custom property, or the fallback if the object does not have it
+func (object ObjectData) StringProperty(name string, fallback string) string {
+	for _, property := range object.Properties {
+		if value, ok := property.Value.(string); ok && property.Name == name {
+			return value
+		}
+	}
+
+	return fallback
+}
+
+// Returns the custom property, or the fallback if the object does not have it
+func (object ObjectData) BoolProperty(name string, fallback bool) bool {
+	for _, property := range object.Properties {
+		if value, ok := property.Value.(bool); ok && property.Name == name {
+			return value
+		}
+	}
+
+	return fallback
+}
+
+// Path modes by the mode property of the object
+var pathModes = map[string]int{
+	"loop":      physics.PathLoop,
+	"pingpong":  physics.PathPingPong,
+	"triggered": physics.PathTriggered,
+}
+
+// Create the entities described by the objects
+func LoadObjects(manager *ecs.Manager, objects []ObjectData) {
+	for _, object := range objects {
+		switch object.Type {
+		case "platform":
+			// The platform moves through the points of the polyline
+			waypoints := make([]physics.Vector2f, 0)
+
+			for _, point := range object.Polyline {
+				waypoints = append(waypoints, physics.NewVector2f(object.X+point.X, object.Y+point.Y))
+			}
+
+			// A platform without a path stays where it is
+			if len(waypoints) == 0 {
+				waypoints = append(waypoints, physics.NewVector2f(object.X, object.Y))
+			}
+
+			// The platform is as big as the object
+			// Polylines have no size in Tiled, so the size of their platforms is set by the properties
+			size := physics.NewVector2f(object.Width, object.Height)
+
+			if size.X <= 0 || size.Y <= 0 {
+				size = physics.NewVector2f(
+					object.FloatProperty("width", 48),
+					object.FloatProperty("height", 16),
+				)
+			}
+
+			NewMovingPlatform(manager, waypoints, size,
+				pathModes[object.StringProperty("mode", "pingpong")],
+				object.FloatProperty("speed", 1),
+				object.BoolProperty("oneway", false),
+			)
+		case "water":
+			// Bodies float in the water by its density
+			NewFluidVolume(manager,
+				physics.NewVector2f(object.X, object.Y),
+				physics.NewVector2f(object.Width, object.Height),
+				physics.NewFluid(object.FloatProperty("density", 1.2), object.FloatProperty("drag", 0.1)),
+			)
+		case "gravity":
+			// Bodies in the zone fall along the gravity of the zone
+			zone := physics.NewGravityZone(physics.NewVector2f(
+				object.FloatProperty("x", 0),
+				object.FloatProperty("y", 0.3),
+			))
+			zone.Priority = int(object.FloatProperty("priority", 0))
+
+			NewGravityArea(manager,
+				physics.NewVector2f(object.X, object.Y),
+				physics.NewVector2f(object.Width, object.Height),
+				zone,
+			)
+		case "wind":
+			// Wind pushes the bodies in the same direction
+			field := physics.NewForceField(physics.NewVector2f(
+				object.FloatProperty("x", 0.2),
+				object.FloatProperty("y", 0),
+			))
+			field.Mask = LayerMask(object.StringProperty("mask", ""))
+
+			NewForceFieldArea(manager,
+				physics.NewVector2f(object.X, object.Y),
+				physics.NewVector2f(object.Width, object.Height),
+				field,
+			)
+		case "updraft":
+			// Updrafts push the bodies up
+			field := physics.NewForceField(physics.NewVector2f(0, -object.FloatProperty("strength", 0.5)))
+			field.Mask = LayerMask(object.StringProperty("mask", ""))
+
+			NewForceFieldArea(manager,
+				physics.NewVector2f(object.X, object.Y),
+				physics.NewVector2f(object.Width, object.Height),
+				field,
+			)
+		case "repulsor":
+			// Repulsors push the bodies away from their center, and attract them with a negative strength
+			field := physics.NewRadialForceField(object.FloatProperty("strength", 0.5))
+			field.Falloff = falloffs[object.StringProperty("falloff", "linear")]
+			field.Mask = LayerMask(object.StringProperty("mask", ""))
+
+			NewForceFieldArea(manager,
+				physics.NewVector2f(object.X, object.Y),
+				physics.NewVector2f(object.Width, object.Height),
+				field,
+			)
+		case "rope", "chain":
+			// The rope hangs from the position of the object to the end of its polyline, or straight down
+			start := physics.NewVector2f(object.X, object.Y)
+			end := start.Add(physics.NewVector2f(0, object.FloatProperty("length", 64)))
+
+			if len(object.Polyline) > 1 {
+				last := object.Polyline[len(object.Polyline)-1]
+				end = start.Add(physics.NewVector2f(last.X, last.Y))
+			}
+
+			NewRope(manager, start, end, int(object.FloatProperty("segments", 8)), object.Type == "chain")
+		case "ladder":
+			// The ladder covers the area of the object
+			NewLadder(manager,
+				physics.NewVector2f(object.X, object.Y),
+				physics.NewVector2f(object.Width, object.Height),
+			)
+		}
+	}
+}
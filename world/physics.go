@@ -1,8 +1,6 @@
 package world
 
 import (
-	"fmt"
-
 	// Game packages
 	"github.com/plutial/game/ecs"
 	"github.com/plutial/game/physics"
@@ -12,59 +10,359 @@ import (
 func UpdateTilePhysics(manager *ecs.Manager, body *physics.Body, force *physics.Force, tiles []int) {
 }
 
-// Update all the entites with a body and force
-func UpdatePhysics(manager *ecs.Manager) {
-	// Get all the entities which have the body component and the force component
-	entities := ecs.GetEntities2[physics.Body, physics.Force](manager)
+// Returns the material of the entity
+// Entities without a material use the default material of the world
+func GetMaterial(manager *ecs.Manager, id int) physics.PhysicsMaterial {
+	if ecs.HasComponent[physics.PhysicsMaterial](manager, id) {
+		return *ecs.GetComponent[physics.PhysicsMaterial](manager, id)
+	}
+
+	return ecs.GetResource[physics.PhysicsSettings](manager).DefaultMaterial
+}
+
+// Returns true if the entity is in the middle of a dash
+func Dashing(manager *ecs.Manager, id int) bool {
+	return ecs.HasComponent[physics.Dash](manager, id) && ecs.GetComponent[physics.Dash](manager, id).Active()
+}
 
+// Returns true if the entity can't be hurt
+// Entities can't be hurt during the start of a dash
+func Invincible(manager *ecs.Manager, id int) bool {
+	return ecs.HasComponent[physics.Dash](manager, id) && ecs.GetComponent[physics.Dash](manager, id).Invincible()
+}
+
+// Returns the colliders of all the tiles and platforms
+func GetColliders(manager *ecs.Manager) []physics.Collider {
 	// Get all tiles
 	tiles := ecs.GetEntities[TileTag](manager)
 
-	// Get all tile bodies
-	var tileBodies []physics.Body
+	// Get all the colliders from the tile bodies
+	var colliders []physics.Collider
 
 	for _, id := range tiles {
 		// Get the tile body
 		body := ecs.GetComponent[physics.Body](manager, id)
 
-		// Add the tile body
-		tileBodies = append(tileBodies, *body)
+		// Add the tile collider
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.OneWay = ecs.HasComponent[PlatformTag](manager, id)
+		collider.Material = GetMaterial(manager, id)
+
+		// Sloped tiles
+		if ecs.HasComponent[physics.Slope](manager, id) {
+			collider.Slope = *ecs.GetComponent[physics.Slope](manager, id)
+		}
+
+		colliders = append(colliders, collider)
+	}
+
+	// Bodies which are not tiles can also be one-way platforms
+	platforms := ecs.GetEntities2[PlatformTag, physics.Body](manager)
+
+	for _, id := range platforms {
+		// Tiles have already been added, and moving platforms are added after
+		if ecs.HasComponent[TileTag](manager, id) || ecs.HasComponent[physics.Path](manager, id) {
+			continue
+		}
+
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.OneWay = true
+		collider.Layer = physics.LayerPlatform
+		collider.Material = GetMaterial(manager, id)
+
+		colliders = append(colliders, collider)
+	}
+
+	colliders = append(colliders, GetMovingPlatforms(manager)...)
+
+	// Ladders can be climbed, and stood on from the top
+	colliders = append(colliders, GetLadders(manager)...)
+
+	// Fluids are not solid, they are only queried
+	colliders = append(colliders, GetFluids(manager)...)
+
+	// Gravity zones and force fields are only queried too
+	colliders = append(colliders, GetGravityZones(manager)...)
+	colliders = append(colliders, GetForceFields(manager)...)
+
+	return colliders
+}
+
+// Returns the colliders of the platforms which move along a path
+// Moving platforms are solid, unless they are one-way platforms
+func GetMovingPlatforms(manager *ecs.Manager) []physics.Collider {
+	movingPlatforms := ecs.GetEntities2[physics.Path, physics.Body](manager)
+
+	var colliders []physics.Collider
+
+	for _, id := range movingPlatforms {
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.OneWay = ecs.HasComponent[PlatformTag](manager, id)
+		collider.Layer = physics.LayerPlatform
+		collider.Material = GetMaterial(manager, id)
+		collider.Velocity = ecs.GetComponent[physics.Path](manager, id).Delta
+
+		colliders = append(colliders, collider)
+	}
+
+	return colliders
+}
+
+// Returns the collision layer of the entity
+func GetLayer(manager *ecs.Manager, id int) uint32 {
+	switch {
+	case ecs.HasComponent[PlayerTag](manager, id):
+		return physics.LayerPlayer
+	case ecs.HasComponent[EnemyTag](manager, id):
+		return physics.LayerEnemy
+	case ecs.HasComponent[ProjectileTag](manager, id):
+		return physics.LayerProjectile
+	case ecs.HasComponent[PlatformTag](manager, id), ecs.HasComponent[physics.Path](manager, id):
+		return physics.LayerPlatform
+	case ecs.HasComponent[ClimbableTag](manager, id):
+		return physics.LayerClimbable
+	case ecs.HasComponent[physics.Fluid](manager, id):
+		return physics.LayerFluid
+	case ecs.HasComponent[physics.GravityZone](manager, id):
+		return physics.LayerGravity
+	case ecs.HasComponent[physics.ForceField](manager, id):
+		return physics.LayerForceField
+	}
+
+	return physics.LayerTile
+}
+
+// Returns how the entity is moved
+// Entities without a body type are kinematic if they follow a path, dynamic if they have a force, and static otherwise
+func GetBodyType(manager *ecs.Manager, id int) int {
+	switch {
+	case ecs.HasComponent[physics.BodyType](manager, id):
+		return ecs.GetComponent[physics.BodyType](manager, id).Type
+	case ecs.HasComponent[physics.Path](manager, id):
+		return physics.BodyKinematic
+	case ecs.HasComponent[physics.Force](manager, id):
+		return physics.BodyDynamic
+	}
+
+	return physics.BodyStatic
+}
+
+// Rebuild the physics world from the bodies of the entities, so it can be queried
+// The tiles, ladders, fluids, zones and fields never move, so only the moving platforms and entities are rebuilt
+func UpdatePhysicsWorld(manager *ecs.Manager) {
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	// The static colliders are only added once
+	if !physicsWorld.Baked() {
+		physicsWorld.Clear()
+
+		for _, collider := range GetColliders(manager) {
+			if GetBodyType(manager, collider.Id) == physics.BodyStatic {
+				physicsWorld.Add(collider)
+			}
+		}
+
+		physicsWorld.Bake()
+	}
+
+	physicsWorld.Clear()
+
+	// Moving platforms
+	for _, collider := range GetMovingPlatforms(manager) {
+		physicsWorld.Add(collider)
+	}
+
+	// Entities which move by themselves
+	entities := ecs.GetEntities2[physics.Body, physics.Force](manager)
+
+	for _, id := range entities {
+		// Platforms have already been added
+		if ecs.HasComponent[PlatformTag](manager, id) {
+			continue
+		}
+
+		body := ecs.GetComponent[physics.Body](manager, id)
+		force := ecs.GetComponent[physics.Force](manager, id)
+
+		collider := physics.NewCollider(*body)
+		collider.Id = id
+		collider.Layer = GetLayer(manager, id)
+		collider.Material = GetMaterial(manager, id)
+		collider.Velocity = force.Acceleration
+
+		physicsWorld.Add(collider)
 	}
+}
+
+// Update all the entites with a body and force
+func UpdatePhysics(manager *ecs.Manager) {
+	// Get all the entities which have the body component and the force component
+	entities := ecs.GetEntities2[physics.Body, physics.Force](manager)
+
+	// The platforms have moved since the last physics update
+	// This is the only rebuild of the step, so the queries before it see the bodies where they were at the start of the last physics update
+	UpdatePhysicsWorld(manager)
+
+	physicsWorld := ecs.GetResource[physics.World](manager)
+	settings := ecs.GetResource[physics.PhysicsSettings](manager)
+
+	// Only report the contacts of this step
+	report := ecs.GetResource[ContactReport](manager)
+	report.Clear()
+
+	// Shapes for the debug overlay
+	debug := physicsWorld.Debug
 
 	for _, id := range entities {
 		// Get the components
 		body := ecs.GetComponent[physics.Body](manager, id)
 		force := ecs.GetComponent[physics.Force](manager, id)
+		material := GetMaterial(manager, id)
+
+		// The gravity zone the body is in decides where it falls
+		force.Gravity = GetGravity(manager, *body)
+
+		switch GetBodyType(manager, id) {
+		case physics.BodyStatic:
+			// Static bodies never move
+			continue
+		case physics.BodyKinematic:
+			// Kinematic bodies move by their force, through everything, and push the dynamic bodies
+			riders := GetRiders(manager, id)
+
+			delta := force.Velocity.Add(force.Acceleration)
+			body.Position = body.Position.Add(delta)
+
+			MovePlatform(manager, id, riders, delta)
+
+			force.Velocity = physics.NewVector2f(0, 0)
+
+			continue
+		}
+
+		// Push the body by the force fields, so that sleeping bodies are woken by them
+		ApplyForceFields(manager, id, *body, force)
+
+		// Sleeping bodies are skipped, until they are pushed or the ground under them is gone
+		if ecs.HasComponent[physics.BodyType](manager, id) {
+			bodyType := ecs.GetComponent[physics.BodyType](manager, id)
+
+			if bodyType.Sleeping {
+				ground := physicsWorld.Nearby(body.Expand(physics.NewVector2f(1, 1)), physics.LayerTile|physics.LayerPlatform)
+
+				if !force.Disturbed() && body.Supported(ground, force.Gravity) {
+					continue
+				}
+
+				bodyType.Wake()
+			}
+		}
 
 		// Apply gravity
-		// Projectiles aren't affected
-		if !ecs.HasComponent[ProjectileTag](manager, id) {
-			force.UpdateGravity()
+		// Projectiles and dashing entities aren't affected
+		// Controlled entities change their own gravity
+		gravity := force.Gravity.Scale(material.GravityScale)
+
+		switch {
+		case ecs.HasComponent[ProjectileTag](manager, id), Dashing(manager, id):
+			gravity = physics.NewVector2f(0, 0)
+		case ecs.HasComponent[physics.PlatformerController](manager, id):
+			controller := ecs.GetComponent[physics.PlatformerController](manager, id)
+			controller.UpdateGravity(force, material)
+		default:
+			force.UpdateGravity(*settings, material)
 		}
 
+		// Float in the fluids
+		// Bodies without gravity are only slowed down
+		UpdateBuoyancy(manager, id, body, force, gravity, material)
+
+		// Add the pushes of this step to the momentum
+		force.IntegrateForces(material)
+
 		// Apply friction
 		if !ecs.HasComponent[ProjectileTag](manager, id) {
-			force.Friction()
+			force.Friction(material)
+		}
+
+		// Grappled bodies swing around the anchor
+		if ecs.HasComponent[physics.Grapple](manager, id) {
+			ecs.GetComponent[physics.Grapple](manager, id).Constrain(*body, force)
 		}
 
 		// Update acceleration
-		force.Velocity.X += force.Acceleration.X
-		force.Velocity.Y += force.Acceleration.Y
+		force.Velocity = force.Velocity.Add(force.Acceleration)
+
+		// Conveyor belts carry the bodies standing on them
+		force.Carry()
+
+		// Only the tiles and platforms near the body can be hit
+		// Projectiles also hit the enemies
+		mask := physics.LayerTile | physics.LayerPlatform
+		if ecs.HasComponent[ProjectileTag](manager, id) {
+			mask |= physics.LayerEnemy
+		}
+
+		area := body.SweptArea(force.Velocity)
+		colliders := physicsWorld.Nearby(area, mask)
+
+		debug.AddBroadPhase(area)
 
 		// Handle tile collisions
 		// This MUST be handled at the end AFTER acceleration has been applied
-		fmt.Println(force.Velocity)
-		body.CollidiesWithDynamicBodies(tileBodies, force)
+		switch {
+		case ecs.HasComponent[physics.Bullet](manager, id):
+			// Bullets stop where they first hit, so they can't pass through thin tiles
+			bodyShape := physics.NewBodyShape(physics.ShapeBox)
+			if ecs.HasComponent[physics.BodyShape](manager, id) {
+				bodyShape = *ecs.GetComponent[physics.BodyShape](manager, id)
+			}
 
-		fmt.Println(force.Velocity)
-		fmt.Println(force.Collisions, "\n")
+			bullet := ecs.GetComponent[physics.Bullet](manager, id)
+			bullet.Sweep(*body, bodyShape, colliders, force)
+		case ecs.HasComponent[physics.BodyShape](manager, id):
+			// Round shapes slide off the corners of the tiles
+			shape := ecs.GetComponent[physics.BodyShape](manager, id).Fit(*body)
+			body.CollidesWithShape(shape, colliders, force)
+		default:
+			body.CollidiesWithDynamicBodies(colliders, force)
+		}
+
+		// Report the contacts of the entity
+		for i := range force.Collisions.Contacts {
+			force.Collisions.Contacts[i].Id = id
+			report.Contacts = append(report.Contacts, force.Collisions.Contacts[i])
+
+			debug.AddContact(force.Collisions.Contacts[i])
+		}
+
+		debug.AddVelocity(*body, force.Velocity)
 
 		// Update the body position
-		body.Position.X += force.Velocity.X
-		body.Position.Y += force.Velocity.Y
+		body.Position = body.Position.Add(force.Velocity)
+
+		// Bounce off the surfaces which were hit
+		force.Bounce(*settings, material)
+
+		// Remove the tiny floating point differences between runs
+		if settings.Deterministic {
+			body.Quantize(settings.Quantum)
+			force.Quantize(settings.Quantum)
+		}
+
+		// Put the body to sleep once it has come to rest
+		if ecs.HasComponent[physics.BodyType](manager, id) {
+			ecs.GetComponent[physics.BodyType](manager, id).UpdateSleep(force, *settings)
+		}
 
 		// Reset the velocity after calculation
-		force.Velocity.X = 0
-		force.Velocity.Y = 0
+		force.Velocity = physics.NewVector2f(0, 0)
 	}
 }
@@ -0,0 +1,173 @@
+package world
+
+import (
+	"image/color"
+	"slices"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/physics"
+)
+
+// Create a platform which moves along the waypoints
+func NewMovingPlatform(manager *ecs.Manager, waypoints []physics.Vector2f, size physics.Vector2f, mode int, speed float64, oneWay bool) int {
+	id := manager.NewEntity()
+
+	// Add components
+	sprite := ecs.AddComponent[gfx.Sprite](manager, id)
+	*sprite = gfx.NewSprite(nil)
+	sprite.Color = color.RGBA{139, 90, 43, 255}
+	sprite.Destination.Size = size
+
+	// Body
+	// The platform starts at the first waypoint
+	body := ecs.AddComponent[physics.Body](manager, id)
+	*body = physics.NewBody(waypoints[0], size)
+
+	// Path
+	path := ecs.AddComponent[physics.Path](manager, id)
+	*path = physics.NewPath(waypoints, mode, speed)
+
+	// The platform is moved by its path
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyKinematic)
+
+	// One-way platforms can be jumped through
+	if oneWay {
+		ecs.AddComponent[PlatformTag](manager, id)
+	}
+
+	return id
+}
+
+// Move the platforms along their paths, carrying their riders and pushing the entities in the way
+// This MUST be handled BEFORE the physics update, so the riders move with the platform first
+func UpdatePlatforms(manager *ecs.Manager) {
+	// Get the moving platforms
+	platforms := ecs.GetEntities2[physics.Path, physics.Body](manager)
+
+	for _, platformId := range platforms {
+		path := ecs.GetComponent[physics.Path](manager, platformId)
+		body := ecs.GetComponent[physics.Body](manager, platformId)
+
+		// Find the riders before the platform moves
+		riders := GetRiders(manager, platformId)
+
+		// Triggered platforms start moving when something stands on them
+		if len(riders) > 0 {
+			path.Trigger()
+		}
+
+		// Move the platform
+		delta := path.Update(body)
+
+		MovePlatform(manager, platformId, riders, delta)
+	}
+}
+
+// Returns the entities which are standing on the platform
+func GetRiders(manager *ecs.Manager, platformId int) []int {
+	body := ecs.GetComponent[physics.Body](manager, platformId)
+
+	// Get all the entities which can be carried
+	entities := ecs.GetEntities2[physics.Body, physics.Force](manager)
+
+	riders := make([]int, 0)
+
+	for _, id := range entities {
+		if id == platformId {
+			continue
+		}
+
+		entityBody := ecs.GetComponent[physics.Body](manager, id)
+		entityForce := ecs.GetComponent[physics.Force](manager, id)
+
+		if entityForce.Collisions.Down && entityBody.RidesOn(*body) {
+			riders = append(riders, id)
+		}
+	}
+
+	return riders
+}
+
+// Carries the riders of a platform which has moved by the delta, and pushes the entities in its way
+// The riders MUST be found BEFORE the platform moves
+// The pushed entities are stopped by the physics world, which MUST have been built by the previous physics update (or when the game was created)
+// The platforms in it are still where they were at the end of the previous step
+func MovePlatform(manager *ecs.Manager, platformId int, riders []int, delta physics.Vector2f) {
+	if delta.X == 0 && delta.Y == 0 {
+		return
+	}
+
+	body := ecs.GetComponent[physics.Body](manager, platformId)
+
+	// Get all the entities which can be carried or pushed
+	entities := ecs.GetEntities2[physics.Body, physics.Force](manager)
+
+	physicsWorld := ecs.GetResource[physics.World](manager)
+
+	for _, id := range entities {
+		// Only the dynamic bodies can be pushed
+		if id == platformId || GetBodyType(manager, id) != physics.BodyDynamic {
+			continue
+		}
+
+		entityBody := ecs.GetComponent[physics.Body](manager, id)
+
+		// Riders move with the platform
+		// Other entities are pushed out of the way, unless they can pass through the platform
+		var movement physics.Vector2f
+
+		if slices.Contains(riders, id) {
+			movement = delta
+		} else if !ecs.HasComponent[PlatformTag](manager, platformId) && entityBody.CollidesWithStaticBody(*body) {
+			movement = body.PushOut(*entityBody, delta)
+
+			// Pushed entities may be pushed off the ground
+			if ecs.HasComponent[physics.BodyType](manager, id) {
+				ecs.GetComponent[physics.BodyType](manager, id).Wake()
+			}
+		} else {
+			continue
+		}
+
+		// Stop the entity from being moved into the tiles and the other platforms near it
+		var colliders []physics.Collider
+
+		for _, collider := range physicsWorld.Nearby(entityBody.SweptArea(movement), physics.LayerTile|physics.LayerPlatform) {
+			if collider.Id != platformId {
+				colliders = append(colliders, collider)
+			}
+		}
+
+		force := physics.NewForce(movement, physics.NewVector2f(0, 0))
+		entityBody.CollidiesWithDynamicBodies(colliders, &force)
+
+		entityBody.Position = entityBody.Position.Add(force.Velocity)
+
+		// If the entity is stuck inside a solid platform, it has been crushed
+		if !ecs.HasComponent[PlatformTag](manager, platformId) && entityBody.CollidesWithStaticBody(*body) {
+			CrushEntity(manager, id)
+		}
+	}
+}
+
+// Crushed entities are destroyed, and the player is sent back to where it spawned
+// Entities which can't be hurt are not crushed
+func CrushEntity(manager *ecs.Manager, id int) {
+	if Invincible(manager, id) {
+		return
+	}
+
+	if ecs.HasComponent[PlayerTag](manager, id) {
+		body := ecs.GetComponent[physics.Body](manager, id)
+		body.Position = PlayerSpawn
+
+		force := ecs.GetComponent[physics.Force](manager, id)
+		*force = physics.NewForce(physics.NewVector2f(0, 0), physics.NewVector2f(0, 0))
+
+		return
+	}
+
+	manager.DeleteEntity(id)
+}
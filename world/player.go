@@ -2,6 +2,7 @@ package world
 
 import (
 	"image/color"
+	"log"
 
 	// Game packages
 	"github.com/plutial/game/ecs"
@@ -11,6 +12,9 @@ import (
 
 type PlayerTag bool
 
+// Where the player starts
+var PlayerSpawn = physics.NewVector2f(16, 16)
+
 func NewPlayer(manager *ecs.Manager) {
 	id := manager.NewEntity()
 
@@ -19,22 +23,48 @@ func NewPlayer(manager *ecs.Manager) {
 
 	// Add components
 	sprite := ecs.AddComponent[gfx.Sprite](manager, id)
-	*sprite = gfx.NewSprite(gfx.NewTexture("assets/res/image.png"))
-	sprite.Image = nil
+	*sprite = gfx.NewSprite(nil)
 	sprite.Color = color.RGBA{0, 255, 0, 255}
 
 	// Body
 	body := ecs.AddComponent[physics.Body](manager, id)
 
-	position := physics.NewVector2f(16, 16)
+	position := PlayerSpawn
 	size := physics.NewVector2f(16, 16)
 
 	*body = physics.NewBody(position, size)
 
+	// The sprite has no texture, so it is as big as the body
+	sprite.Destination.Size = size
+
 	// Force
 	force := ecs.AddComponent[physics.Force](manager, id)
 	*force = physics.NewForce(physics.NewVector2f(0, 0), physics.NewVector2f(0, 0))
 
-	// Jump
-	ecs.AddComponent[physics.Jump](manager, id)
+	*ecs.AddComponent[physics.BodyType](manager, id) = physics.NewBodyType(physics.BodyDynamic)
+
+	// Collision shape
+	// The rounded corners slide past the edges of the tiles instead of catching on them
+	*ecs.AddComponent[physics.BodyShape](manager, id) = physics.NewBodyShape(physics.ShapeRoundedBox)
+
+	// Controller
+	controller := ecs.AddComponent[physics.PlatformerController](manager, id)
+	*controller = physics.NewPlatformerController()
+
+	// Dash
+	dash := ecs.AddComponent[physics.Dash](manager, id)
+	*dash = physics.NewDash()
+
+	// Grappling hook
+	grapple := ecs.AddComponent[physics.Grapple](manager, id)
+	*grapple = physics.NewGrapple()
+
+	// Animate the player, or stay a colored rectangle if the sprite sheet can't be loaded
+	sheet, err := gfx.LoadAseprite("assets/res/player.json")
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	AddAnimator(manager, id, sheet)
 }
@@ -0,0 +1,128 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	// Game packages
+	"github.com/plutial/game/ecs"
+	"github.com/plutial/game/gfx"
+	"github.com/plutial/game/physics"
+)
+
+// Colors of the ropes
+var (
+	ropeColor    = color.RGBA{200, 170, 120, 255}
+	grappleColor = color.RGBA{220, 220, 220, 255}
+)
+
+// Create a rope which hangs from the start
+// Chains bend less than ropes
+func NewRope(manager *ecs.Manager, start, end physics.Vector2f, segments int, chain bool) int {
+	id := manager.NewEntity()
+
+	rope := ecs.AddComponent[physics.Verlet](manager, id)
+	*rope = physics.NewRope(start, end, segments)
+
+	if chain {
+		rope.Iterations = 16
+		rope.Stiffen(math.Pi * 3 / 4)
+	}
+
+	return id
+}
+
+// Shoot, reel and release the grappling hook of the player
+// The hook attaches where a ray from the player towards the aim hits a tile
+func UpdateGrapple(manager *ecs.Manager) {
+	playerId := ecs.GetEntities[PlayerTag](manager)[0]
+
+	if !ecs.HasComponent[physics.Grapple](manager, playerId) {
+		return
+	}
+
+	grapple := ecs.GetComponent[physics.Grapple](manager, playerId)
+	body := ecs.GetComponent[physics.Body](manager, playerId)
+	playerInput := ecs.GetResource[PlayerInput](manager)
+
+	// The hook stays attached while the button is held
+	if grapple.Attached && !playerInput.GrappleHeld {
+		grapple.Release()
+	}
+
+	if playerInput.Grapple && !grapple.Attached {
+		physicsWorld := ecs.GetResource[physics.World](manager)
+
+		direction := playerInput.Aim.Sub(body.Center()).Normalize().Scale(grapple.MaxLength)
+
+		if hit, ok := physicsWorld.RaycastFirst(body.Center(), direction, physics.LayerTile); ok {
+			grapple.Attach(hit.Point, body.Center())
+		}
+	}
+
+	if !grapple.Attached {
+		return
+	}
+
+	// Reel in and out
+	if playerInput.ReelIn {
+		grapple.Reel(grapple.ReelSpeed)
+	}
+
+	if playerInput.ReelOut {
+		grapple.Reel(-grapple.ReelSpeed)
+	}
+}
+
+// Let the ropes swing and hang around the tiles
+// This MUST be handled AFTER the physics step, so the grappling hook follows the player
+func UpdateRopes(manager *ecs.Manager) {
+	physicsWorld := ecs.GetResource[physics.World](manager)
+	settings := ecs.GetResource[physics.PhysicsSettings](manager)
+
+	mask := physics.LayerTile | physics.LayerPlatform
+
+	for _, id := range ecs.GetEntities[physics.Verlet](manager) {
+		rope := ecs.GetComponent[physics.Verlet](manager, id)
+
+		rope.Step(settings.Gravity, physicsWorld.Nearby(rope.Bounds(), mask))
+	}
+
+	for _, id := range ecs.GetEntities2[physics.Grapple, physics.Body](manager) {
+		grapple := ecs.GetComponent[physics.Grapple](manager, id)
+		body := ecs.GetComponent[physics.Body](manager, id)
+
+		if !grapple.Attached {
+			continue
+		}
+
+		gravity := settings.Gravity
+		if ecs.HasComponent[physics.Force](manager, id) {
+			gravity = ecs.GetComponent[physics.Force](manager, id).Gravity
+		}
+
+		grapple.UpdateRope(body.Center(), gravity, physicsWorld.Nearby(grapple.Rope.Bounds(), mask))
+	}
+}
+
+// Draw the ropes and the grappling hooks
+func RenderRopes(manager *ecs.Manager) {
+	for _, id := range ecs.GetEntities[physics.Verlet](manager) {
+		renderRope(ropeColor, ecs.GetComponent[physics.Verlet](manager, id).Points())
+	}
+
+	for _, id := range ecs.GetEntities[physics.Grapple](manager) {
+		grapple := ecs.GetComponent[physics.Grapple](manager, id)
+
+		if grapple.Attached {
+			renderRope(grappleColor, grapple.Rope.Points())
+		}
+	}
+}
+
+// Draws the segments between the points
+func renderRope(color color.RGBA, points []physics.Vector2f) {
+	for i := 1; i < len(points); i++ {
+		gfx.RenderLine(color, points[i-1], points[i])
+	}
+}